@@ -0,0 +1,63 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestArgsort(t *testing.T) {
+	d := []int{3, 1, 2, 1}
+
+	assert(t, []int{1, 3, 2, 0}, numerus.IntsArgsort(d, true), true)
+	assert(t, []int{0, 2, 1, 3}, numerus.IntsArgsort(d, false), true)
+	assert(t, []int{3, 1, 2, 1}, d, true)
+
+	assert(t, []int{}, numerus.Argsort([]uint8{}, true), true)
+}
+
+func TestArgsort_Stability(t *testing.T) {
+	for x := range dInts64 {
+		d := make([]int64, len(dInts64[x]))
+		copy(d, dInts64[x])
+
+		for _, asc := range []bool{true, false} {
+			ids := numerus.Ints64Argsort(d, asc)
+
+			for y := 1; y < len(ids); y++ {
+				a, b := d[ids[y-1]], d[ids[y]]
+				if asc {
+					assert(t, true, a <= b, true)
+				} else {
+					assert(t, true, a >= b, true)
+				}
+				if a == b {
+					assert(t, true, ids[y-1] < ids[y], true)
+				}
+			}
+			assert(t, dInts64[x], d, true)
+		}
+	}
+}
+
+func TestFloats64Argsort(t *testing.T) {
+	org := fmt.Sprint(dFloats64NaN)
+
+	f := numerus.Floats64With(numerus.NaNLast)
+
+	got := f.Argsort(dFloats64NaN, false)
+
+	assert(t, []int{2, 0, 4, 7, 3, 6, 1, 5}, got, true)
+
+	f = numerus.Floats64With(numerus.NaNFirst)
+
+	got = f.Argsort(dFloats64NaN, true)
+
+	assert(t, []int{1, 5, 6, 3, 4, 7, 0, 2}, got, true)
+
+	assert(t, org, fmt.Sprint(dFloats64NaN), true)
+}
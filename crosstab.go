@@ -0,0 +1,181 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// CrossTable contains the joint counts of two columns, known as contingency
+// table, where Counts[i][j] is the number of rows where the first column is
+// equal to Rows[i] and the second column is equal to Cols[j].
+//
+type CrossTable[T Number] struct {
+	// Rows contains the classes of the first column.
+	Rows []T
+
+	// Cols contains the classes of the second column.
+	Cols []T
+
+	Counts [][]int
+
+	// RowTotals contains the sum of each row in Counts.
+	RowTotals []int
+
+	// ColTotals contains the sum of each column in Counts.
+	ColTotals []int
+
+	// Total is the sum of all Counts.
+	Total int
+}
+
+//
+// CrossTab count the occurrence of each pair of class in `rows` and `cols`,
+// where the pair is `(rows[x], cols[x])`.
+// It can be used to create confusion matrix, by passing the actual classes
+// as `rows` and the predicted classes as `cols`.
+//
+// The classes for each column is taken from `rowClasses` and `colClasses`,
+// like in Counts; value that does not exist in the classes is not counted.
+// If the classes is empty, it will be discovered from the data and sorted
+// in ascending order.
+// NaN is equal to NaN and placed at the end of discovered classes.
+//
+// If the length of `rows` and `cols` is not equal, it will return an error
+// that match ErrLengthMismatch.
+//
+// Example, given rows [1 1 2 2], cols [1 2 2 2], and empty classes, the
+// result is
+//
+//	      1  2 | total
+//	1     1  1 |   2
+//	2     0  2 |   2
+//	-----------+------
+//	total 1  3 |   4
+//
+func CrossTab[T Number](rows, cols, rowClasses, colClasses []T) (
+	ct *CrossTable[T], err error,
+) {
+	const fn = "numerus: CrossTab"
+
+	return crossTab(fn, rows, cols, rowClasses, colClasses,
+		compareNaNLast[T], false)
+}
+
+//
+// crossTab create the cross table using `cmp` to sort the discovered
+// classes.
+// If `skipNaN` is true, NaN is not discovered and not counted.
+//
+func crossTab[T Number](fn string, rows, cols, rowClasses, colClasses []T,
+	cmp func(a, b T) int, skipNaN bool,
+) (ct *CrossTable[T], err error) {
+	if len(rows) != len(cols) {
+		return nil, &LengthError{
+			Func: fn,
+			Len:  len(cols),
+			Exp:  len(rows),
+		}
+	}
+
+	if len(rowClasses) == 0 {
+		rowClasses = discoverClasses(rows, cmp, skipNaN)
+	}
+	if len(colClasses) == 0 {
+		colClasses = discoverClasses(cols, cmp, skipNaN)
+	}
+
+	ct = &CrossTable[T]{
+		Rows:      rowClasses,
+		Cols:      colClasses,
+		Counts:    make([][]int, len(rowClasses)),
+		RowTotals: make([]int, len(rowClasses)),
+		ColTotals: make([]int, len(colClasses)),
+	}
+	for x := range ct.Counts {
+		ct.Counts[x] = make([]int, len(colClasses))
+	}
+
+	rowPos := newClassIndex(rowClasses, skipNaN)
+	colPos := newClassIndex(colClasses, skipNaN)
+
+	for x := range rows {
+		i := rowPos.find(rows[x])
+		if i < 0 {
+			continue
+		}
+		j := colPos.find(cols[x])
+		if j < 0 {
+			continue
+		}
+		ct.Counts[i][j]++
+		ct.RowTotals[i]++
+		ct.ColTotals[j]++
+		ct.Total++
+	}
+
+	return ct, nil
+}
+
+//
+// discoverClasses return the distinct values in `d` sorted using `cmp`.
+//
+func discoverClasses[T Number](d []T, cmp func(a, b T) int, skipNaN bool) (
+	classes []T,
+) {
+	ft := newFreqTable(d)
+	if skipNaN {
+		ft.removeNaN()
+	}
+
+	sortFrequencies(ft.freqs, FrequencyByValue, cmp)
+
+	classes = make([]T, len(ft.freqs))
+	for x, f := range ft.freqs {
+		classes[x] = f.Value
+	}
+	return classes
+}
+
+//
+// classIndex map each class into their index in the list of classes.
+//
+type classIndex[T Number] struct {
+	pos map[T]int
+
+	// nan is the index of NaN in classes, or -1 if classes does not
+	// contains NaN or NaN is skipped.
+	nan int
+}
+
+func newClassIndex[T Number](classes []T, skipNaN bool) (ci *classIndex[T]) {
+	ci = &classIndex[T]{
+		pos: make(map[T]int, len(classes)),
+		nan: -1,
+	}
+	for x, c := range classes {
+		if isNaN(c) {
+			if ci.nan < 0 && !skipNaN {
+				ci.nan = x
+			}
+			continue
+		}
+		if _, ok := ci.pos[c]; !ok {
+			ci.pos[c] = x
+		}
+	}
+	return ci
+}
+
+//
+// find return the index of class `v`, or -1 if its not exist.
+//
+func (ci *classIndex[T]) find(v T) int {
+	if isNaN(v) {
+		return ci.nan
+	}
+	x, ok := ci.pos[v]
+	if !ok {
+		return -1
+	}
+	return x
+}
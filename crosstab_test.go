@@ -0,0 +1,77 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestCrossTab(t *testing.T) {
+	rows := []int{2, 1, 2, 1, 3}
+	cols := []int{2, 1, 2, 2, 1}
+
+	got, err := numerus.IntsCrossTab(rows, cols, nil, nil)
+
+	assert(t, nil, err, true)
+	assert(t, &numerus.CrossTable[int]{
+		Rows: []int{1, 2, 3},
+		Cols: []int{1, 2},
+		Counts: [][]int{
+			{1, 1},
+			{0, 2},
+			{1, 0},
+		},
+		RowTotals: []int{2, 2, 1},
+		ColTotals: []int{2, 3},
+		Total:     5,
+	}, got, true)
+
+	// Value that does not exist in classes is not counted.
+	got, _ = numerus.IntsCrossTab(rows, cols, []int{2, 1}, []int{2})
+
+	assert(t, [][]int{{2}, {1}}, got.Counts, true)
+	assert(t, []int{2, 1}, got.RowTotals, true)
+	assert(t, []int{3}, got.ColTotals, true)
+	assert(t, 3, got.Total, true)
+
+	_, err = numerus.Ints64CrossTab([]int64{1}, nil, nil, nil)
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+}
+
+func TestFloats64CrossTab(t *testing.T) {
+	rows := []float64{nan, 1, 1, nan}
+	cols := []float64{0, 0, nan, 0}
+
+	cases := []struct {
+		policy numerus.NaNPolicy
+		exp    string
+	}{{
+		policy: numerus.NaNPropagate,
+		exp:    "[1 NaN] [0 NaN] [[1 1] [2 0]] [2 2] [3 1] 4",
+	}, {
+		policy: numerus.NaNFirst,
+		exp:    "[NaN 1] [NaN 0] [[0 2] [1 1]] [2 2] [1 3] 4",
+	}, {
+		policy: numerus.NaNSkip,
+		exp:    "[1] [0] [[1]] [1] [1] 1",
+	}}
+
+	for _, c := range cases {
+		f := numerus.Floats64With(c.policy)
+
+		ct, err := f.CrossTab(rows, cols, nil, nil)
+
+		assert(t, nil, err, true)
+
+		got := fmt.Sprint(ct.Rows, ct.Cols, ct.Counts,
+			ct.RowTotals, ct.ColTotals, ct.Total)
+
+		assert(t, c.exp, got, true)
+	}
+}
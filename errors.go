@@ -0,0 +1,77 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"errors"
+	"fmt"
+)
+
+// List of errors returned by functions in this package.
+var (
+	// ErrLengthMismatch define an error when two or more slices that
+	// should have the same length have different length.
+	ErrLengthMismatch = errors.New("length of slices does not match")
+
+	// ErrInvalidType define an error when the type of parameter is not
+	// supported.
+	ErrInvalidType = errors.New("unsupported type")
+
+	// ErrIndexOutOfRange define an error when the index is less than zero
+	// or greater or equal to the length of slice.
+	ErrIndexOutOfRange = errors.New("index out of range")
+
+	// ErrNegativeWeight define an error when the weight is negative or
+	// NaN.
+	ErrNegativeWeight = errors.New("negative weight")
+)
+
+//
+// IndexError define an error when the index `Index` is out of range of
+// slice with length `Len`.
+// It can be checked using errors.Is with ErrIndexOutOfRange.
+//
+type IndexError struct {
+	// Func is the name of function that return the error.
+	Func  string
+	Index int
+	Len   int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("%s: index %d out of range [0:%d]", e.Func, e.Index,
+		e.Len)
+}
+
+//
+// Is will return true if target is ErrIndexOutOfRange.
+//
+func (e *IndexError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+//
+// LengthError define an error when the length of slice, `Len`, is not equal
+// to the expected length, `Exp`.
+// It can be checked using errors.Is with ErrLengthMismatch.
+//
+type LengthError struct {
+	// Func is the name of function that return the error.
+	Func string
+	Len  int
+	Exp  int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s: %s: got %d, want %d", e.Func,
+		ErrLengthMismatch, e.Len, e.Exp)
+}
+
+//
+// Is will return true if target is ErrLengthMismatch.
+//
+func (e *LengthError) Is(target error) bool {
+	return target == ErrLengthMismatch
+}
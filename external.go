@@ -0,0 +1,578 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//
+// ExternalFormat define the encoding of values that is read and written by
+// Floats64ExternalSort.
+//
+type ExternalFormat int
+
+// List of external format.
+const (
+	// FormatBinary encode each value as 8 bytes IEEE-754 float in
+	// little-endian order, and each index as 8 bytes integer in
+	// little-endian order.
+	FormatBinary ExternalFormat = iota
+
+	// FormatText encode each value and index as decimal text, one per
+	// line.
+	// Empty line is ignored on reading.
+	FormatText
+)
+
+//
+// DefaultChunkSize is the default number of values that is sorted in memory
+// by Floats64ExternalSort.
+//
+const DefaultChunkSize = 1 << 20
+
+//
+// DefaultMaxFanIn is the default maximum number of sorted runs that is
+// merged at once by Floats64ExternalSort.
+//
+const DefaultMaxFanIn = 64
+
+//
+// ExternalSortOptions define the options for Floats64ExternalSort.
+//
+type ExternalSortOptions struct {
+	// IndexWriter if its not nil, the original row index of each sorted
+	// value is written into it, using the same format as the values.
+	IndexWriter io.Writer
+
+	// TempDir is the directory where the sorted runs is stored.
+	// If its empty, it will use the default directory for temporary
+	// files, os.TempDir.
+	TempDir string
+
+	// ChunkSize is the maximum number of values that is sorted in memory.
+	// If its less or equal to zero, it will use DefaultChunkSize.
+	ChunkSize int
+
+	// MaxFanIn is the maximum number of sorted runs that is opened and
+	// merged at once.
+	// If there are more runs than MaxFanIn, the runs is merged in several
+	// passes into intermediate runs, until the number of runs is less or
+	// equal to MaxFanIn.
+	// If its less than 2, it will use DefaultMaxFanIn.
+	MaxFanIn int
+
+	// Format is the encoding of input, output, and index.
+	Format ExternalFormat
+}
+
+//
+// Floats64ExternalSort sort the float values that is read from `r` and write
+// the sorted values into `w`, for data that does not fit in memory.
+//
+// The values is read in chunks of `opts.ChunkSize`.
+// Each chunk is sorted using Floats64InplaceMergesort and stored in
+// temporary file as sorted run, and then all runs is merged into `w` using
+// k-way merge.
+// At most `opts.MaxFanIn` runs is opened at the same time; if there are more
+// runs, they are merged first into intermediate runs.
+// If all values fit in one chunk, no temporary file is created.
+// All temporary files is removed before the function return.
+//
+// The sort is stable and NaN is placed at the end of output.
+// If `opts` is nil, it will use the default options.
+//
+func Floats64ExternalSort(r io.Reader, w io.Writer, asc bool,
+	opts *ExternalSortOptions,
+) (err error) {
+	return Floats64With(NaNPropagate).ExternalSort(r, w, asc, opts)
+}
+
+//
+// ExternalSort sort the values from `r` into `w` like Floats64ExternalSort,
+// using the NaN policy of `f` to sort each chunk and to merge the runs.
+//
+func (f Floats64Ops) ExternalSort(r io.Reader, w io.Writer, asc bool,
+	opts *ExternalSortOptions,
+) (err error) {
+	const fn = "numerus: Floats64ExternalSort"
+
+	if opts == nil {
+		opts = &ExternalSortOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	maxFanIn := opts.MaxFanIn
+	if maxFanIn < 2 {
+		maxFanIn = DefaultMaxFanIn
+	}
+
+	var (
+		in    = newExternalReader(r, opts.Format)
+		out   = newExternalWriter(w, opts.IndexWriter, opts.Format)
+		runs  []*externalRun
+		chunk = make([]float64, 0, chunkSize)
+		base  int
+		eof   bool
+	)
+
+	cmp := func(a, b float64) int {
+		return f.compare(a, b, asc)
+	}
+
+	defer func() {
+		errRemove := removeExternalRuns(runs)
+		if err == nil && errRemove != nil {
+			err = fmt.Errorf("%s: %w", fn, errRemove)
+		}
+	}()
+
+	for !eof {
+		chunk = chunk[:0]
+		for len(chunk) < chunkSize {
+			v, errRead := in.readValue()
+			if errRead == io.EOF {
+				eof = true
+				break
+			}
+			if errRead != nil {
+				return fmt.Errorf("%s: %w", fn, errRead)
+			}
+			chunk = append(chunk, v)
+		}
+		if len(chunk) == 0 && len(runs) > 0 {
+			break
+		}
+
+		ids := f.IndirectSort(chunk, asc)
+		for x := range ids {
+			ids[x] += base
+		}
+		base += len(chunk)
+
+		if eof && len(runs) == 0 {
+			// All values fit in one chunk.
+			for x, v := range chunk {
+				err = out.write(v, ids[x])
+				if err != nil {
+					return fmt.Errorf("%s: %w", fn, err)
+				}
+			}
+			return out.flush()
+		}
+
+		run, errRun := newExternalRun(opts.TempDir, chunk, ids)
+		if run != nil {
+			runs = append(runs, run)
+		}
+		if errRun != nil {
+			return fmt.Errorf("%s: %w", fn, errRun)
+		}
+	}
+
+	for len(runs) > maxFanIn {
+		group := runs[:maxFanIn]
+
+		run, errRun := mergeExternalRuns(opts.TempDir, group, cmp)
+		if run != nil {
+			runs = append(runs, run)
+		}
+		if errRun != nil {
+			return fmt.Errorf("%s: %w", fn, errRun)
+		}
+
+		runs = runs[maxFanIn:]
+		err = removeExternalRuns(group)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fn, err)
+		}
+	}
+
+	err = externalMerge(runs, cmp, out.write)
+	if err == nil {
+		err = out.flush()
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", fn, err)
+	}
+	return nil
+}
+
+//
+// externalReader read the values in binary or text format.
+//
+type externalReader struct {
+	r      *bufio.Reader
+	format ExternalFormat
+	buf    [8]byte
+}
+
+func newExternalReader(r io.Reader, format ExternalFormat) *externalReader {
+	return &externalReader{
+		r:      bufio.NewReader(r),
+		format: format,
+	}
+}
+
+func (in *externalReader) readValue() (v float64, err error) {
+	if in.format == FormatBinary {
+		_, err = io.ReadFull(in.r, in.buf[:])
+		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				err = fmt.Errorf("incomplete value: %w", err)
+			}
+			return 0, err
+		}
+		bits := binary.LittleEndian.Uint64(in.buf[:])
+		return math.Float64frombits(bits), nil
+	}
+
+	var line string
+	for {
+		line, err = in.r.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if len(line) > 0 {
+			return strconv.ParseFloat(line, 64)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+//
+// externalWriter write the values and their index in binary or text format.
+//
+type externalWriter struct {
+	w      *bufio.Writer
+	iw     *bufio.Writer
+	format ExternalFormat
+	buf    []byte
+}
+
+func newExternalWriter(w, iw io.Writer, format ExternalFormat) (
+	out *externalWriter,
+) {
+	out = &externalWriter{
+		w:      bufio.NewWriter(w),
+		format: format,
+		buf:    make([]byte, 0, 32),
+	}
+	if iw != nil {
+		out.iw = bufio.NewWriter(iw)
+	}
+	return out
+}
+
+func (out *externalWriter) write(v float64, id int) (err error) {
+	out.buf = out.buf[:0]
+	if out.format == FormatBinary {
+		out.buf = binary.LittleEndian.AppendUint64(out.buf,
+			math.Float64bits(v))
+	} else {
+		out.buf = strconv.AppendFloat(out.buf, v, 'g', -1, 64)
+		out.buf = append(out.buf, '\n')
+	}
+	_, err = out.w.Write(out.buf)
+	if err != nil || out.iw == nil {
+		return err
+	}
+
+	out.buf = out.buf[:0]
+	if out.format == FormatBinary {
+		out.buf = binary.LittleEndian.AppendUint64(out.buf,
+			uint64(id))
+	} else {
+		out.buf = strconv.AppendInt(out.buf, int64(id), 10)
+		out.buf = append(out.buf, '\n')
+	}
+	_, err = out.iw.Write(out.buf)
+	return err
+}
+
+func (out *externalWriter) flush() (err error) {
+	err = out.w.Flush()
+	if err != nil {
+		return err
+	}
+	if out.iw != nil {
+		return out.iw.Flush()
+	}
+	return nil
+}
+
+//
+// externalRun is a sorted chunk of values that is stored in temporary file.
+// Each value is stored along with their index, in binary format.
+//
+// The file is only opened while the run is written or merged, so the number
+// of open files does not depend on the number of runs.
+//
+type externalRun struct {
+	name string
+	file *os.File
+	w    *bufio.Writer
+	r    *bufio.Reader
+	buf  [16]byte
+
+	// The current value and index at the head of run.
+	v  float64
+	id int
+}
+
+//
+// newExternalRun create new run in directory `dir` that contains the sorted
+// values `d` and their index `ids`.
+//
+func newExternalRun(dir string, d []float64, ids []int) (
+	run *externalRun, err error,
+) {
+	run, err = createExternalRun(dir)
+	if err != nil {
+		return run, err
+	}
+	for x, v := range d {
+		err = run.write(v, ids[x])
+		if err != nil {
+			return run, err
+		}
+	}
+	return run, run.finish()
+}
+
+//
+// mergeExternalRuns merge the sorted `runs` into new run in directory `dir`,
+// using `cmp` to compare the values.
+// If the merge fail, the new run is removed.
+//
+func mergeExternalRuns(dir string, runs []*externalRun,
+	cmp func(a, b float64) int,
+) (
+	run *externalRun, err error,
+) {
+	run, err = createExternalRun(dir)
+	if err != nil {
+		return run, err
+	}
+
+	err = externalMerge(runs, cmp, run.write)
+	if err == nil {
+		err = run.finish()
+	}
+	if err != nil {
+		_ = run.remove()
+		return nil, err
+	}
+	return run, nil
+}
+
+//
+// removeExternalRuns remove all of the `runs` and return the first error.
+//
+func removeExternalRuns(runs []*externalRun) (err error) {
+	for _, run := range runs {
+		errRemove := run.remove()
+		if err == nil {
+			err = errRemove
+		}
+	}
+	return err
+}
+
+//
+// createExternalRun create an empty run in directory `dir` that is opened
+// for writing.
+//
+func createExternalRun(dir string) (run *externalRun, err error) {
+	file, err := os.CreateTemp(dir, "numerus-run-*")
+	if err != nil {
+		return nil, err
+	}
+	run = &externalRun{
+		name: file.Name(),
+		file: file,
+		w:    bufio.NewWriter(file),
+	}
+	return run, nil
+}
+
+//
+// write the value `v` and their index `id` at the end of run.
+//
+func (run *externalRun) write(v float64, id int) (err error) {
+	binary.LittleEndian.PutUint64(run.buf[:8], math.Float64bits(v))
+	binary.LittleEndian.PutUint64(run.buf[8:], uint64(id))
+	_, err = run.w.Write(run.buf[:])
+	return err
+}
+
+//
+// finish flush and close the run after writing.
+//
+func (run *externalRun) finish() (err error) {
+	err = run.w.Flush()
+	run.w = nil
+	errClose := run.close()
+	if err == nil {
+		err = errClose
+	}
+	return err
+}
+
+//
+// open the run for reading from the beginning.
+//
+func (run *externalRun) open() (err error) {
+	run.file, err = os.Open(run.name)
+	if err != nil {
+		return err
+	}
+	run.r = bufio.NewReader(run.file)
+	return nil
+}
+
+//
+// next read the next value and index into the head of run.
+//
+func (run *externalRun) next() (err error) {
+	_, err = io.ReadFull(run.r, run.buf[:])
+	if err != nil {
+		return err
+	}
+	run.v = math.Float64frombits(binary.LittleEndian.Uint64(run.buf[:8]))
+	run.id = int(binary.LittleEndian.Uint64(run.buf[8:]))
+	return nil
+}
+
+func (run *externalRun) close() (err error) {
+	if run.file == nil {
+		return nil
+	}
+	err = run.file.Close()
+	run.file = nil
+	run.r = nil
+	return err
+}
+
+func (run *externalRun) remove() (err error) {
+	err = run.close()
+	errRemove := os.Remove(run.name)
+	if err == nil {
+		err = errRemove
+	}
+	return err
+}
+
+//
+// externalHeap is min-heap of runs ordered by their head value, or by their
+// index if the values is equal.
+// Since the index is the position of value in the input, ordering by index
+// keep the merge stable regardless of the order of runs.
+//
+type externalHeap struct {
+	runs []*externalRun
+	cmp  func(a, b float64) int
+}
+
+func (h *externalHeap) Len() int { return len(h.runs) }
+
+func (h *externalHeap) Less(i, j int) bool {
+	c := h.cmp(h.runs[i].v, h.runs[j].v)
+	if c != 0 {
+		return c < 0
+	}
+	return h.runs[i].id < h.runs[j].id
+}
+
+func (h *externalHeap) Swap(i, j int) {
+	h.runs[i], h.runs[j] = h.runs[j], h.runs[i]
+}
+
+func (h *externalHeap) Push(x interface{}) {
+	h.runs = append(h.runs, x.(*externalRun))
+}
+
+func (h *externalHeap) Pop() interface{} {
+	n := len(h.runs) - 1
+	run := h.runs[n]
+	h.runs = h.runs[:n]
+	return run
+}
+
+//
+// externalMerge merge the sorted runs using k-way merge and pass each value
+// and their index, in order, into `write`.
+// Each run is opened at the start of merge and closed once its exhausted.
+//
+func externalMerge(runs []*externalRun, cmp func(a, b float64) int,
+	write func(v float64, id int) error,
+) (err error) {
+	h := &externalHeap{
+		runs: make([]*externalRun, 0, len(runs)),
+		cmp:  cmp,
+	}
+
+	defer func() {
+		for _, run := range runs {
+			errClose := run.close()
+			if err == nil {
+				err = errClose
+			}
+		}
+	}()
+
+	for _, run := range runs {
+		err = run.open()
+		if err != nil {
+			return err
+		}
+		err = run.next()
+		if err == io.EOF {
+			_ = run.close()
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		h.runs = append(h.runs, run)
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		run := h.runs[0]
+
+		err = write(run.v, run.id)
+		if err != nil {
+			return err
+		}
+
+		err = run.next()
+		if err == io.EOF {
+			heap.Pop(h)
+			err = run.close()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		heap.Fix(h, 0)
+	}
+
+	return nil
+}
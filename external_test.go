@@ -0,0 +1,215 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func externalInput(size int) (in []float64) {
+	r := rand.New(rand.NewSource(1))
+	in = make([]float64, size)
+	for x := range in {
+		// Use small range of value to produce many equal elements.
+		in[x] = float64(r.Intn(200)-100) / 4
+	}
+	return in
+}
+
+func TestFloats64ExternalSortBinary(t *testing.T) {
+	in := externalInput(1050)
+	dir := t.TempDir()
+
+	var input, output, index bytes.Buffer
+	for _, v := range in {
+		_ = binary.Write(&input, binary.LittleEndian, v)
+	}
+
+	opts := &numerus.ExternalSortOptions{
+		IndexWriter: &index,
+		TempDir:     dir,
+		ChunkSize:   100,
+		Format:      numerus.FormatBinary,
+	}
+
+	err := numerus.Floats64ExternalSort(&input, &output, false, opts)
+
+	assert(t, nil, err, true)
+
+	got := make([]float64, len(in))
+	ids := make([]int64, len(in))
+	_ = binary.Read(&output, binary.LittleEndian, got)
+	_ = binary.Read(&index, binary.LittleEndian, ids)
+
+	exp := make([]float64, len(in))
+	copy(exp, in)
+	expIds := numerus.Floats64IndirectSort(exp, false)
+
+	assert(t, exp, got, true)
+	assert(t, numerus.IntsTo64(expIds), ids, true)
+
+	// All temporary files should be removed.
+	files, _ := os.ReadDir(dir)
+
+	assert(t, 0, len(files), true)
+}
+
+func TestFloats64ExternalSortMaxFanIn(t *testing.T) {
+	in := externalInput(1000)
+	dir := t.TempDir()
+
+	var input bytes.Buffer
+	for _, v := range in {
+		_ = binary.Write(&input, binary.LittleEndian, v)
+	}
+
+	exp := make([]float64, len(in))
+	copy(exp, in)
+	expIds := numerus.Floats64IndirectSort(exp, true)
+
+	for _, maxFanIn := range []int{2, 3, 7} {
+		var output, index bytes.Buffer
+
+		// 100 runs of 10 values, merged in several passes.
+		opts := &numerus.ExternalSortOptions{
+			IndexWriter: &index,
+			TempDir:     dir,
+			ChunkSize:   10,
+			MaxFanIn:    maxFanIn,
+		}
+
+		err := numerus.Floats64ExternalSort(
+			bytes.NewReader(input.Bytes()), &output, true, opts)
+
+		assert(t, nil, err, true)
+
+		got := make([]float64, len(in))
+		ids := make([]int64, len(in))
+		_ = binary.Read(&output, binary.LittleEndian, got)
+		_ = binary.Read(&index, binary.LittleEndian, ids)
+
+		assert(t, exp, got, true)
+		assert(t, numerus.IntsTo64(expIds), ids, true)
+
+		files, _ := os.ReadDir(dir)
+
+		assert(t, 0, len(files), true)
+	}
+}
+
+func TestFloats64OpsExternalSort(t *testing.T) {
+	in := []float64{3, nan, 1, nan, 2}
+
+	var input bytes.Buffer
+	for _, v := range in {
+		_ = binary.Write(&input, binary.LittleEndian, v)
+	}
+
+	for _, c := range []struct {
+		policy numerus.NaNPolicy
+		exp    string
+	}{
+		{numerus.NaNFirst, "[NaN NaN 3 2 1]"},
+		{numerus.NaNLast, "[3 2 1 NaN NaN]"},
+	} {
+		var output, index bytes.Buffer
+
+		opts := &numerus.ExternalSortOptions{
+			IndexWriter: &index,
+			TempDir:     t.TempDir(),
+			ChunkSize:   2,
+			MaxFanIn:    2,
+		}
+
+		f := numerus.Floats64With(c.policy)
+
+		err := f.ExternalSort(bytes.NewReader(input.Bytes()),
+			&output, false, opts)
+
+		assert(t, nil, err, true)
+
+		got := make([]float64, len(in))
+		ids := make([]int64, len(in))
+		_ = binary.Read(&output, binary.LittleEndian, got)
+		_ = binary.Read(&index, binary.LittleEndian, ids)
+
+		assert(t, c.exp, fmt.Sprint(got), true)
+		for x, id := range ids {
+			assert(t, fmt.Sprint(in[id]), fmt.Sprint(got[x]), true)
+		}
+	}
+}
+
+func TestFloats64ExternalSortText(t *testing.T) {
+	in := externalInput(333)
+
+	var input strings.Builder
+	for _, v := range in {
+		input.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		input.WriteString("\n\n")
+	}
+
+	for _, chunkSize := range []int{0, 10, 333} {
+		var output, index bytes.Buffer
+
+		opts := &numerus.ExternalSortOptions{
+			IndexWriter: &index,
+			ChunkSize:   chunkSize,
+			Format:      numerus.FormatText,
+		}
+
+		err := numerus.Floats64ExternalSort(
+			strings.NewReader(input.String()), &output, true, opts)
+
+		assert(t, nil, err, true)
+
+		lines := strings.Fields(output.String())
+		idLines := strings.Fields(index.String())
+
+		assert(t, len(in), len(lines), true)
+		assert(t, len(in), len(idLines), true)
+
+		got := make([]float64, len(lines))
+		for x := range lines {
+			got[x], _ = strconv.ParseFloat(lines[x], 64)
+			id, _ := strconv.Atoi(idLines[x])
+
+			assert(t, in[id], got[x], true)
+		}
+
+		assert(t, true, sort.Float64sAreSorted(got), true)
+	}
+}
+
+func TestFloats64ExternalSortError(t *testing.T) {
+	var output bytes.Buffer
+
+	err := numerus.Floats64ExternalSort(strings.NewReader("1\nx\n"),
+		&output, true, &numerus.ExternalSortOptions{
+			Format: numerus.FormatText,
+		})
+
+	assert(t, nil, err, false)
+
+	err = numerus.Floats64ExternalSort(bytes.NewReader([]byte{1, 2, 3}),
+		&output, true, nil)
+
+	assert(t, nil, err, false)
+
+	err = numerus.Floats64ExternalSort(bytes.NewReader(nil), &output,
+		true, nil)
+
+	assert(t, nil, err, true)
+	assert(t, 0, output.Len(), true)
+}
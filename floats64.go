@@ -4,33 +4,33 @@
 
 package numerus
 
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
 //
 // Floats64FindMax given slice of float, find the maximum value in slice and
 // and return it with their index.
 //
 // If data is empty, return -1 in value and index, and false in ok.
 //
+// If data contains NaN, it will return NaN and index of the first NaN.
+//
 // Example, given data: [0.0 0.1 0.2 0.2 0.4], it will return 0.4 as max and 4
 // as index of maximum value.
 //
 func Floats64FindMax(d []float64) (maxv float64, maxi int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
-
-	x := 0
-	maxv = d[x]
-	maxi = x
-
-	for x = 1; x < l; x++ {
-		if d[x] > maxv {
-			maxv = d[x]
-			maxi = x
-		}
-	}
+	return Floats64With(NaNPropagate).FindMax(d)
+}
 
-	return maxv, maxi, true
+//
+// FindMax find the maximum value in slice like Floats64FindMax, but NaN is
+// ignored if the policy of `f` is not NaNPropagate.
+//
+func (f Floats64Ops) FindMax(d []float64) (maxv float64, maxi int, ok bool) {
+	return f.findExtreme(d, true)
 }
 
 //
@@ -39,49 +39,94 @@ func Floats64FindMax(d []float64) (maxv float64, maxi int, ok bool) {
 //
 // If data is empty, return -1 in value and index, and false in ok.
 //
+// If data contains NaN, it will return NaN and index of the first NaN.
+//
 // Example, given data: [0.0 0.1 0.2 0.2 0.4], it will return 0 as min and 0
 // as index of minimum value.
 //
 func Floats64FindMin(d []float64) (minv float64, mini int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
+	return Floats64With(NaNPropagate).FindMin(d)
+}
+
+//
+// FindMin find the minimum value in slice like Floats64FindMin, but NaN is
+// ignored if the policy of `f` is not NaNPropagate.
+//
+func (f Floats64Ops) FindMin(d []float64) (minv float64, mini int, ok bool) {
+	return f.findExtreme(d, false)
+}
 
-	x := 0
-	minv = d[x]
-	mini = x
+//
+// findExtreme find the maximum value in `d` if `isMax` is true, otherwise
+// find the minimum value, by following the NaN policy in `f`.
+//
+func (f Floats64Ops) findExtreme(d []float64, isMax bool) (
+	v float64, i int, ok bool,
+) {
+	v, i = -1, -1
+	propagate := f.policy == NaNPropagate
 
-	for x = 1; x < l; x++ {
-		if d[x] < minv {
-			minv = d[x]
-			mini = x
+	for x, dv := range d {
+		if math.IsNaN(dv) {
+			if propagate {
+				return dv, x, true
+			}
+			continue
+		}
+		if i < 0 || (isMax && dv > v) || (!isMax && dv < v) {
+			v = dv
+			i = x
 		}
 	}
 
-	return minv, mini, true
+	return v, i, i >= 0
 }
 
 //
 // Floats64Sum return sum of slice of float64.
+// The sum of data that contains NaN is NaN.
 //
 func Floats64Sum(d []float64) (sum float64) {
+	return Floats64With(NaNPropagate).Sum(d)
+}
+
+//
+// Sum return sum of slice of float64 like Floats64Sum, but NaN is ignored if
+// the policy of `f` is not NaNPropagate.
+//
+func (f Floats64Ops) Sum(d []float64) (sum float64) {
+	if f.policy == NaNPropagate {
+		return Sum(d)
+	}
 	for _, v := range d {
-		sum += v
+		if !math.IsNaN(v) {
+			sum += v
+		}
 	}
-	return
+	return sum
 }
 
 //
 // Floats64Count will count number of class in data.
+// If class is NaN, it will count the number of NaN in data.
 //
 func Floats64Count(d []float64, class float64) (count int) {
-	if len(d) <= 0 {
-		return
-	}
+	return Floats64With(NaNPropagate).Count(d, class)
+}
 
+//
+// Count will count number of class in data like Floats64Count, but if the
+// policy of `f` is NaNSkip, NaN is never counted.
+//
+func (f Floats64Ops) Count(d []float64, class float64) (count int) {
+	if !math.IsNaN(class) {
+		return Count(d, class)
+	}
+	if f.policy == NaNSkip {
+		return 0
+	}
 	for _, v := range d {
-		if v == class {
+		if math.IsNaN(v) {
 			count++
 		}
 	}
@@ -98,18 +143,34 @@ func Floats64Count(d []float64, class float64) (count int) {
 //	0 : 1   -> 2
 //	1 : 2   -> 1
 //
+// The data is scanned once, using the frequency table from Frequencies.
+// The NaN in classes is counted like in Floats64Count.
 //
 func Floats64Counts(d, classes []float64) (counts []int) {
+	return Floats64With(NaNPropagate).Counts(d, classes)
+}
+
+//
+// Counts will count each class in data, with the NaN in classes counted like
+// in Count of `f`.
+//
+func (f Floats64Ops) Counts(d, classes []float64) (counts []int) {
 	if len(classes) <= 0 {
 		return
 	}
 
-	counts = make([]int, len(classes))
+	ft := newFreqTable(d)
 
+	counts = ft.counts(classes)
+	if ft.nan < 0 || f.policy == NaNSkip {
+		return counts
+	}
 	for x, c := range classes {
-		counts[x] = Floats64Count(d, c)
+		if math.IsNaN(c) {
+			counts[x] = ft.freqs[ft.nan].Count
+		}
 	}
-	return
+	return counts
 }
 
 //
@@ -127,6 +188,14 @@ func Floats64Counts(d, classes []float64) (counts []int) {
 // `true`.
 //
 func Floats64MaxCountOf(d, classes []float64) (float64, bool) {
+	return Floats64With(NaNPropagate).MaxCountOf(d, classes)
+}
+
+//
+// MaxCountOf return the class with maximum count in data, with the NaN in
+// classes counted like in Count of `f`.
+//
+func (f Floats64Ops) MaxCountOf(d, classes []float64) (float64, bool) {
 	if len(classes) == 0 {
 		return -1, false
 	}
@@ -134,7 +203,7 @@ func Floats64MaxCountOf(d, classes []float64) (float64, bool) {
 		return -2, false
 	}
 
-	counts := Floats64Counts(d, classes)
+	counts := f.Counts(d, classes)
 
 	_, maxi, _ := IntsFindMax(counts)
 	if maxi < 0 {
@@ -148,29 +217,38 @@ func Floats64MaxCountOf(d, classes []float64) (float64, bool) {
 // Floats64Swap swap two indices value of 64bit float.
 //
 func Floats64Swap(d []float64, x, y int) {
-	if x == y {
-		return
-	}
-	if len(d) <= 1 || x > len(d) || y > len(d) {
-		return
-	}
+	Swap(d, x, y)
+}
 
-	tmp := d[x]
-	d[x] = d[y]
-	d[y] = tmp
+//
+// Floats64SafeSwap swap two indices value of 64bit float, or return an error
+// if one of the index is out of range.
+// See SafeSwap for more information.
+//
+func Floats64SafeSwap(d []float64, x, y int) error {
+	return SafeSwap(d, x, y)
 }
 
 //
 // Floats64IsExist will return true if value `v` exist in slice of `d`,
 // otherwise it will return false.
 //
+// If `v` is NaN, it will return true if data contains NaN.
+//
 func Floats64IsExist(d []float64, v float64) bool {
-	for _, x := range d {
-		if v == x {
-			return true
-		}
+	return Floats64With(NaNPropagate).IsExist(d, v)
+}
+
+//
+// IsExist will return true if value `v` exist in slice of `d` like
+// Floats64IsExist, but if the policy of `f` is NaNSkip, it will return false
+// for NaN.
+//
+func (f Floats64Ops) IsExist(d []float64, v float64) bool {
+	if math.IsNaN(v) {
+		return f.Count(d, v) > 0
 	}
-	return false
+	return IsExist(d, v)
 }
 
 //
@@ -182,217 +260,917 @@ func Floats64IsExist(d []float64, v float64) bool {
 // - `l` is starting index of slice to be sorted.
 // - `r` is end index of slice to be sorted.
 //
+// NaN is placed at the end of range.
+//
 func Floats64InsertionSort(d []float64, ids []int, l, r int, asc bool) {
-	for x := l; x < r; x++ {
-		for y := x + 1; y < r; y++ {
-			if asc {
-				if d[x] > d[y] {
-					IntsSwap(ids, x, y)
-					Floats64Swap(d, x, y)
-				}
-			} else {
-				if d[x] < d[y] {
-					IntsSwap(ids, x, y)
-					Floats64Swap(d, x, y)
-				}
-			}
-		}
-	}
+	Floats64With(NaNPropagate).InsertionSort(d, ids, l, r, asc)
+}
+
+//
+// InsertionSort sort the data like Floats64InsertionSort, but NaN is placed at
+// the beginning of range if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) InsertionSort(d []float64, ids []int, l, r int, asc bool) {
+	l, r = partitionNaN(d, ids, l, r, f.policy == NaNFirst)
+	InsertionSort(d, ids, l, r, asc)
 }
 
 //
 // Floats64SortByIndex will sort the slice of float using sorted index.
 //
 func Floats64SortByIndex(d *[]float64, sortedIds []int) {
-	newd := make([]float64, len(*d))
-
-	for i := range sortedIds {
-		newd[i] = (*d)[sortedIds[i]]
-	}
+	SortByIndex(d, sortedIds)
+}
 
-	(*d) = newd
+//
+// Floats64SafeSortByIndex will sort the slice of float using sorted index,
+// or return an error if the index is not valid.
+// See SafeSortByIndex for more information.
+//
+func Floats64SafeSortByIndex(d *[]float64, sortedIds []int) error {
+	return SafeSortByIndex(d, sortedIds)
 }
 
 //
 // Floats64InplaceMergesort in-place merge-sort without memory allocation.
 //
+// NaN is placed at the end of range.
+// Only if data contains NaN, a temporary buffer is allocated to move the
+// NaN.
+//
 func Floats64InplaceMergesort(d []float64, idx []int, l, r int, asc bool) {
-	// (0) If data length == Threshold, then
-	if l+SortThreshold >= r {
-		// (0.1) use insertion sort.
-		Floats64InsertionSort(d, idx, l, r, asc)
-		return
+	Floats64With(NaNPropagate).InplaceMergesort(d, idx, l, r, asc)
+}
+
+//
+// InplaceMergesort sort the data like Floats64InplaceMergesort, but NaN is
+// placed at the beginning of range if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) InplaceMergesort(d []float64, idx []int, l, r int,
+	asc bool,
+) {
+	l, r = partitionNaN(d, idx, l, r, f.policy == NaNFirst)
+	InplaceMergesort(d, idx, l, r, asc)
+}
+
+//
+// Floats64InplaceMergesortParallel sort the slice of 64bit float using in-place
+// merge-sort, with the left and right halves sorted concurrently by at most
+// `workers` goroutines.
+// NaN is moved to the end of range before the rest of data is sorted.
+// See InplaceMergesortParallel for more information.
+//
+func Floats64InplaceMergesortParallel(d []float64, idx []int, l, r int,
+	asc bool, workers int,
+) {
+	Floats64With(NaNPropagate).InplaceMergesortParallel(d, idx, l, r,
+		asc, workers)
+}
+
+//
+// InplaceMergesortParallel sort the data concurrently like
+// Floats64InplaceMergesortParallel, but NaN is moved to the beginning of range
+// if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) InplaceMergesortParallel(d []float64, idx []int, l, r int,
+	asc bool, workers int,
+) {
+	l, r = partitionNaN(d, idx, l, r, f.policy == NaNFirst)
+	InplaceMergesortParallel(d, idx, l, r, asc, workers)
+}
+
+//
+// Floats64IndirectSort will sort the data and return the sorted index.
+//
+func Floats64IndirectSort(d []float64, asc bool) (sortedIdx []int) {
+	return Floats64With(NaNPropagate).IndirectSort(d, asc)
+}
+
+//
+// IndirectSort sort the data and return the sorted index like
+// Floats64IndirectSort, with NaN placed following the policy of `f`.
+//
+func (f Floats64Ops) IndirectSort(d []float64, asc bool) (sortedIdx []int) {
+	dlen := len(d)
+
+	sortedIdx = make([]int, dlen)
+	for i := 0; i < dlen; i++ {
+		sortedIdx[i] = i
+	}
+
+	f.InplaceMergesort(d, sortedIdx, 0, dlen, asc)
+
+	return
+}
+
+//
+// Floats64Sort sort the slice of 64bit float using the algorithm in `opts`.
+//
+// NaN is moved to the end of range, before the rest of data is sorted.
+// See Sort for more information.
+//
+func Floats64Sort(d []float64, idx []int, l, r int, asc bool,
+	opts *SortOptions,
+) {
+	Floats64With(NaNPropagate).Sort(d, idx, l, r, asc, opts)
+}
+
+//
+// Sort sort the data like Floats64Sort, but NaN is moved to the beginning of
+// range if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) Sort(d []float64, idx []int, l, r int, asc bool,
+	opts *SortOptions,
+) {
+	l, r = partitionNaN(d, idx, l, r, f.policy == NaNFirst)
+	Sort(d, idx, l, r, asc, opts)
+}
+
+//
+// Floats64TopK return the `k` largest values in `d` and their index, sorted
+// in descending order.
+//
+// NaN is considered larger than any other values, so the result of k=1 is
+// equal to Floats64FindMax.
+//
+// See TopK for more information.
+//
+func Floats64TopK(d []float64, k int) (vals []float64, ids []int) {
+	return Floats64With(NaNPropagate).TopK(d, k)
+}
+
+//
+// TopK return the `k` largest values in `d` like Floats64TopK, but NaN is
+// ignored if the policy of `f` is not NaNPropagate.
+//
+func (f Floats64Ops) TopK(d []float64, k int) (vals []float64, ids []int) {
+	if f.policy == NaNPropagate {
+		return partialSort(d, k, func(a, b float64) bool {
+			return a > b || (math.IsNaN(a) && !math.IsNaN(b))
+		}, nil)
 	}
+	return partialSort(d, k, func(a, b float64) bool {
+		return a > b
+	}, math.IsNaN)
+}
 
-	// (1) Divide into left and right.
-	res := (r + l) % 2
-	c := (r + l) / 2
-	if res == 1 {
-		c++
+//
+// Floats64BottomK return the `k` smallest values in `d` and their index,
+// sorted in ascending order.
+//
+// NaN is considered smaller than any other values, so the result of k=1 is
+// equal to Floats64FindMin.
+//
+// See BottomK for more information.
+//
+func Floats64BottomK(d []float64, k int) (vals []float64, ids []int) {
+	return Floats64With(NaNPropagate).BottomK(d, k)
+}
+
+//
+// BottomK return the `k` smallest values in `d` like Floats64BottomK, but NaN
+// is ignored if the policy of `f` is not NaNPropagate.
+//
+func (f Floats64Ops) BottomK(d []float64, k int) (vals []float64, ids []int) {
+	if f.policy == NaNPropagate {
+		return partialSort(d, k, func(a, b float64) bool {
+			return a < b || (math.IsNaN(a) && !math.IsNaN(b))
+		}, nil)
 	}
+	return partialSort(d, k, func(a, b float64) bool {
+		return a < b
+	}, math.IsNaN)
+}
 
-	// (2) Sort left.
-	Floats64InplaceMergesort(d, idx, l, c, asc)
+//
+// Floats64NthElement partially sort the data in place, so the element at
+// position `n` is the element that would be there if `d` is sorted in
+// ascending order using Floats64InplaceMergesort.
+// NaN is moved to the end of data.
+//
+// See NthElement for more information.
+//
+func Floats64NthElement(d []float64, idx []int, n int) (
+	v float64, i int, ok bool,
+) {
+	return Floats64With(NaNPropagate).NthElement(d, idx, n)
+}
+
+//
+// NthElement partially sort the data in place like Floats64NthElement, with
+// NaN moved to the beginning or the end of data following the policy of `f`.
+//
+// If the policy is NaNSkip, NaN is moved to the end and `n` must be less than
+// the number of non-NaN values, otherwise it will return -1 in value and
+// index, and false in ok.
+//
+func (f Floats64Ops) NthElement(d []float64, idx []int, n int) (
+	v float64, i int, ok bool,
+) {
+	if n < 0 || n >= len(d) {
+		return -1, -1, false
+	}
 
-	// (3) Sort right.
-	Floats64InplaceMergesort(d, idx, c, r, asc)
+	l, r := partitionNaN(d, idx, 0, len(d),
+		f.policy == NaNFirst)
 
-	// (4) Merge sorted left and right.
-	if asc {
-		if d[c-1] <= d[c] {
-			// (4.1) If the last element of the left is lower then
-			// the first element of the right, i.e. [1 2] [3 4];
-			// no merging needed, return immediately.
-			return
-		}
-	} else {
-		if d[c-1] >= d[c] {
-			return
-		}
+	if f.policy == NaNSkip && n >= r {
+		return -1, -1, false
+	}
+	if n >= l && n < r {
+		introselect(d, idx, l, r, n, false)
 	}
 
-	floats64InplaceMerge(d, idx, l, c, r, asc)
+	i = -1
+	if idx != nil {
+		i = idx[n]
+	}
+	return d[n], i, true
 }
 
 //
-// Let `x` be the first index of left-side, `y` be the first index of
-// the right-side, and `r` as length of slice `d`
+// Floats64Select return the `n`-th smallest element in `d` and its index,
+// without modifying `d`.
+// See Floats64NthElement for more information.
 //
-func floats64InplaceMerge(d []float64, idx []int, x, y, r int, asc bool) {
-	var ylast int
+func Floats64Select(d []float64, n int) (v float64, i int, ok bool) {
+	return Floats64With(NaNPropagate).Select(d, n)
+}
 
-	// (4.3) Loop until either x or y reached the maximum slice.
-	for x < r && y < r {
-		// (4.3.1) IF DATA[x] <= DATA[y]
-		if asc {
-			if d[x] <= d[y] {
-				x++
+//
+// Select return the `n`-th smallest element in `d` and its index, without
+// modifying `d`, using the NaN policy of `f`.
+//
+// If the policy is NaNSkip, `n` is the position among the non-NaN values, so
+// the result of Median is equal to Select with `n` is (k-1)/2, where k is the
+// number of non-NaN values.
+// See NthElement for more information.
+//
+func (f Floats64Ops) Select(d []float64, n int) (v float64, i int, ok bool) {
+	if n < 0 || n >= len(d) {
+		return -1, -1, false
+	}
 
-				// (4.3.1.2) IF x >= y THEN GOTO 4.3.4
-				if x >= y {
-					goto next
-				}
+	tmp := make([]float64, len(d))
+	copy(tmp, d)
+	ids := IntCreateSeq(0, len(d)-1)
 
-				// (4.3.1.3) GOTO 4.3
-				continue
-			}
-		} else {
-			if d[x] >= d[y] {
-				x++
+	return f.NthElement(tmp, ids, n)
+}
 
-				if x >= y {
-					goto next
-				}
+//
+// Floats64Median return the lower median of data and its index, without
+// modifying `d`.
+//
+// If data contains NaN, it will return NaN and index of the first NaN.
+//
+func Floats64Median(d []float64) (v float64, i int, ok bool) {
+	return Floats64With(NaNPropagate).Median(d)
+}
 
-				continue
+//
+// Median return the lower median of data like Floats64Median, but if the
+// policy of `f` is not NaNPropagate it will return the median of non-NaN
+// values.
+//
+func (f Floats64Ops) Median(d []float64) (v float64, i int, ok bool) {
+	var (
+		vals = make([]float64, 0, len(d))
+		ids  = make([]int, 0, len(d))
+	)
+
+	for x, dv := range d {
+		if math.IsNaN(dv) {
+			if f.policy == NaNPropagate {
+				return dv, x, true
 			}
+			continue
 		}
+		vals = append(vals, dv)
+		ids = append(ids, x)
+	}
 
-		// (4.3.2) LET YLAST := the next DATA[y] that is less DATA[x]
-		ylast = floats64MoveY(d, x, y, r, asc)
+	return NthElement(vals, ids, (len(vals)-1)/2)
+}
 
-		// (4.3.3) SWAP DATA, X, Y, YLAST
-		floats64Multiswap(d, idx, x, y, ylast)
+//
+// Floats64Argsort return the index that would sort the data, without
+// modifying `d`.
+// The index of NaN is placed at the end, in their original order.
+//
+// See Argsort for more information.
+//
+func Floats64Argsort(d []float64, asc bool) (sortedIdx []int) {
+	return Floats64With(NaNPropagate).Argsort(d, asc)
+}
 
-	next:
-		// (4.3.4) LET Y := the minimum value between x and r on `d`
-		floats64MinY(d, &x, &y, r, asc)
+//
+// Argsort return the index that would sort the data like Floats64Argsort,
+// but the index of NaN is placed at the beginning if the policy of `f` is
+// NaNFirst.
+//
+func (f Floats64Ops) Argsort(d []float64, asc bool) (sortedIdx []int) {
+	sortedIdx = make([]int, len(d))
+	for i := range sortedIdx {
+		sortedIdx[i] = i
 	}
+
+	slices.SortStableFunc(sortedIdx, func(a, b int) int {
+		return f.compare(d[a], d[b], asc)
+	})
+
+	return sortedIdx
 }
 
-// (4.3.4) LET Y := the minimum value between x and r on `d`
-func floats64MinY(d []float64, x, y *int, r int, asc bool) {
-	for *x < r {
-		if asc {
-			*y = floats64Min(d, *x, r)
-		} else {
-			*y = floats64Max(d, *x, r)
+//
+// compare compare two float like compare function, with NaN placed
+// following the NaN policy in `f`.
+//
+func (f Floats64Ops) compare(a, b float64, asc bool) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	if aNaN || bNaN {
+		if aNaN && bNaN {
+			return 0
 		}
-
-		if *y != *x {
-			break
+		c := 1
+		if f.policy == NaNFirst {
+			c = -1
+		}
+		if bNaN {
+			c = -c
 		}
-		(*x)++
+		return c
 	}
+	return compare(a, b, asc)
 }
 
-func floats64MoveY(d []float64, x, y, r int, asc bool) int {
-	yorg := y
-	y++
-	for y < r {
-		if asc {
-			if d[y] >= d[x] {
-				break
-			}
-			if d[y] < d[yorg] {
-				break
-			}
-		} else {
-			if d[y] <= d[x] {
-				break
-			}
-			if d[y] > d[yorg] {
-				break
-			}
-		}
-		y++
+//
+// Floats64Merge merge the already sorted slices of float into one sorted
+// slice.
+// Each slice must be sorted with NaN placed at the end, as the result of
+// Floats64InplaceMergesort.
+//
+// See Merge for more information.
+//
+func Floats64Merge(asc bool, ds ...[]float64) (out []float64, src, ids []int) {
+	return Floats64With(NaNPropagate).Merge(asc, ds...)
+}
+
+//
+// Merge merge the sorted slices like Floats64Merge, where each slice must be
+// sorted with NaN placed following the policy of `f`, as the result of
+// InplaceMergesort of `f`.
+//
+func (f Floats64Ops) Merge(asc bool, ds ...[]float64) (
+	out []float64, src, ids []int,
+) {
+	return kwayMerge(ds, func(a, b float64) int {
+		return f.compare(a, b, asc)
+	})
+}
+
+//
+// Floats64LowerBound return the index of first element in sorted slice that
+// is not placed before `v`.
+// The `d` must be sorted with NaN placed at the end, as the result of
+// Floats64InplaceMergesort.
+//
+// See LowerBound for more information.
+//
+func Floats64LowerBound(d []float64, v float64, asc bool) int {
+	return Floats64With(NaNPropagate).LowerBound(d, v, asc)
+}
+
+//
+// LowerBound search the sorted slice like Floats64LowerBound, where `d` must be
+// sorted with NaN placed following the policy of `f`.
+//
+func (f Floats64Ops) LowerBound(d []float64, v float64, asc bool) int {
+	return sort.Search(len(d), func(i int) bool {
+		return f.compare(d[i], v, asc) >= 0
+	})
+}
+
+//
+// Floats64UpperBound return the index of first element in sorted slice that
+// is placed after `v`.
+// See Floats64LowerBound for more information.
+//
+func Floats64UpperBound(d []float64, v float64, asc bool) int {
+	return Floats64With(NaNPropagate).UpperBound(d, v, asc)
+}
+
+//
+// UpperBound return the index of first element in sorted slice that is placed
+// after `v`, where NaN in `d` is placed following the policy of `f`.
+// See LowerBound for more information.
+//
+func (f Floats64Ops) UpperBound(d []float64, v float64, asc bool) int {
+	return sort.Search(len(d), func(i int) bool {
+		return f.compare(d[i], v, asc) > 0
+	})
+}
+
+//
+// Floats64EqualRange return the range of index that contains elements equal
+// to `v` in sorted slice.
+// If `v` is NaN, it will return the range of NaN.
+//
+func Floats64EqualRange(d []float64, v float64, asc bool) (lo, hi int) {
+	return Floats64With(NaNPropagate).EqualRange(d, v, asc)
+}
+
+//
+// EqualRange return the range of index that contains elements equal to `v` in
+// sorted slice, where NaN in `d` is placed following the policy of `f`.
+//
+func (f Floats64Ops) EqualRange(d []float64, v float64, asc bool) (lo, hi int) {
+	return f.LowerBound(d, v, asc), f.UpperBound(d, v, asc)
+}
+
+//
+// Floats64IsExistSorted will return true if value `v` exist in sorted slice
+// `d`, otherwise it will return false.
+// It is equal to Floats64IsExist but run in O(log n).
+//
+func Floats64IsExistSorted(d []float64, v float64, asc bool) bool {
+	return Floats64With(NaNPropagate).IsExistSorted(d, v, asc)
+}
+
+//
+// IsExistSorted will return true if value `v` exist in sorted slice `d`, where
+// NaN in `d` is placed following the policy of `f`.
+// If the policy is NaNSkip, it will return false if `v` is NaN.
+//
+func (f Floats64Ops) IsExistSorted(d []float64, v float64, asc bool) bool {
+	return f.CountSorted(d, v, asc) > 0
+}
+
+//
+// Floats64CountSorted will count number of `v` in sorted slice `d`.
+// It is equal to Floats64Count but run in O(log n).
+//
+func Floats64CountSorted(d []float64, v float64, asc bool) int {
+	return Floats64With(NaNPropagate).CountSorted(d, v, asc)
+}
+
+//
+// CountSorted will count number of `v` in sorted slice `d`, where NaN in `d` is
+// placed following the policy of `f`.
+// If the policy is NaNSkip, NaN is never counted.
+//
+func (f Floats64Ops) CountSorted(d []float64, v float64, asc bool) int {
+	if math.IsNaN(v) && f.policy == NaNSkip {
+		return 0
 	}
-	return y
+	lo, hi := f.EqualRange(d, v, asc)
+	return hi - lo
 }
 
-func floats64Multiswap(d []float64, idx []int, x, y, ylast int) int {
-	for y < ylast {
-		IntsSwap(idx, x, y)
-		Floats64Swap(d, x, y)
-		x++
-		y++
-		if y >= ylast {
-			return y
+//
+// Floats64Nearest return the index of element in sorted slice `d` that has
+// the smallest distance to `v`, ignoring NaN.
+// If two elements have the same distance, the lower index is returned.
+//
+// If tolerance `tol` is not negative, the distance must be less or equal to
+// `tol`, otherwise it will return -1 and false.
+// If `d` does not contain non-NaN value or `v` is NaN, it will return -1 and
+// false.
+//
+func Floats64Nearest(d []float64, v float64, asc bool, tol float64) (
+	i int, ok bool,
+) {
+	return Floats64With(NaNPropagate).Nearest(d, v, asc, tol)
+}
+
+//
+// Nearest return the index of element in sorted slice `d` that has the
+// smallest distance to `v`, like Floats64Nearest, where NaN in `d` is placed
+// following the policy of `f`.
+//
+func (f Floats64Ops) Nearest(d []float64, v float64, asc bool, tol float64) (
+	i int, ok bool,
+) {
+	if math.IsNaN(v) {
+		return -1, false
+	}
+
+	var (
+		x    = f.LowerBound(d, v, asc)
+		dist = math.Inf(1)
+	)
+
+	i = -1
+	for _, c := range []int{x - 1, x} {
+		if c < 0 || c >= len(d) || math.IsNaN(d[c]) {
+			continue
+		}
+		cdist := math.Abs(d[c] - v)
+		if d[c] == v {
+			// Handle the infinity, where Inf-Inf is NaN.
+			cdist = 0
 		}
-		if d[x] <= d[y] {
-			return y
+		if cdist < dist || i < 0 {
+			i = c
+			dist = cdist
 		}
 	}
+	if i < 0 {
+		return -1, false
+	}
+	if tol >= 0 && dist > tol {
+		return -1, false
+	}
+	return i, true
+}
 
-	return y
+//
+// Floats64IsSorted will return true if data is sorted in ascending order if
+// `asc` is true, or in descending order otherwise.
+// NaN must be placed at the end of data, and multiple NaN is considered
+// equal.
+//
+// See IsSorted for more information.
+//
+func Floats64IsSorted(d []float64, asc, strict bool) bool {
+	return Floats64With(NaNPropagate).IsSorted(d, asc, strict)
+}
+
+//
+// IsSorted check the order of data like Floats64IsSorted, but NaN must be
+// placed at the beginning of data if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) IsSorted(d []float64, asc, strict bool) bool {
+	return isSortedFunc(len(d), strict, func(i, j int) int {
+		return f.compare(d[i], d[j], asc)
+	})
+}
+
+//
+// Floats64Runs return the natural ascending and strictly descending runs in
+// data.
+// NaN is compared as larger than any other values.
+//
+// See Runs for more information.
+//
+func Floats64Runs(d []float64) (runs []Run) {
+	return Floats64With(NaNPropagate).Runs(d)
 }
 
-func floats64Min(d []float64, l, r int) (m int) {
-	min := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] <= min {
-			min = d[l]
-			m = l
+//
+// Runs return the runs in data like Floats64Runs, but NaN is compared as
+// smaller than any other values if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) Runs(d []float64) (runs []Run) {
+	return runsFunc(len(d), func(i, j int) int {
+		return f.compare(d[i], d[j], true)
+	})
+}
+
+//
+// Floats64Rank return the rank of each element in `d`.
+//
+// All NaN are equal and ranked at the end, after all other values.
+//
+// See Rank for more information.
+//
+func Floats64Rank(d []float64, asc bool, method RankMethod) (ranks []int) {
+	return Floats64With(NaNPropagate).Rank(d, asc, method)
+}
+
+//
+// Rank return the rank of each element in `d` like Floats64Rank, but NaN is
+// ranked at the beginning if the policy of `f` is NaNFirst.
+// If the policy is NaNSkip, NaN is not ranked and their rank is 0.
+//
+func (f Floats64Ops) Rank(d []float64, asc bool, method RankMethod) (
+	ranks []int,
+) {
+	ranks = make([]int, len(d))
+
+	rankGroups(f.rankIdx(d, asc), func(a, b int) bool {
+		return floats64Equal(d[a], d[b])
+	}, func(ids []int, start, end, dense int) {
+		for x := start; x < end; x++ {
+			ranks[ids[x]] = rankOf(method, x, start, end, dense)
 		}
-	}
-	return
+	})
+
+	return ranks
 }
 
-func floats64Max(d []float64, l, r int) (m int) {
-	maxv := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] >= maxv {
-			maxv = d[l]
-			m = l
+//
+// Floats64RankAverage return the rank of each element in `d`, where equal
+// elements are assigned the average of their ranks.
+//
+// All NaN are equal and ranked at the end, after all other values.
+//
+func Floats64RankAverage(d []float64, asc bool) (ranks []float64) {
+	return Floats64With(NaNPropagate).RankAverage(d, asc)
+}
+
+//
+// RankAverage return the average rank of each element in `d` like
+// Floats64RankAverage, but NaN is ranked at the beginning if the policy of `f`
+// is NaNFirst.
+// If the policy is NaNSkip, NaN is not ranked and their rank is NaN.
+//
+func (f Floats64Ops) RankAverage(d []float64, asc bool) (ranks []float64) {
+	ranks = make([]float64, len(d))
+	if f.policy == NaNSkip {
+		for x, v := range d {
+			if math.IsNaN(v) {
+				ranks[x] = v
+			}
 		}
 	}
-	return m
+
+	rankGroups(f.rankIdx(d, asc), func(a, b int) bool {
+		return floats64Equal(d[a], d[b])
+	}, func(ids []int, start, end, _ int) {
+		avg := float64(start+1+end) / 2
+		for x := start; x < end; x++ {
+			ranks[ids[x]] = avg
+		}
+	})
+
+	return ranks
 }
 
 //
-// Floats64IndirectSort will sort the data and return the sorted index.
+// rankIdx return the sorted index of `d` that will be ranked.
+// If the NaN policy is NaNSkip, the index of NaN is removed.
 //
-func Floats64IndirectSort(d []float64, asc bool) (sortedIdx []int) {
-	dlen := len(d)
+func (f Floats64Ops) rankIdx(d []float64, asc bool) (ids []int) {
+	ids = f.Argsort(d, asc)
+	if f.policy != NaNSkip {
+		return ids
+	}
+	// NaN is placed at the end of index on NaNSkip.
+	n := len(ids)
+	for n > 0 && math.IsNaN(d[ids[n-1]]) {
+		n--
+	}
+	return ids[:n]
+}
 
-	sortedIdx = make([]int, dlen)
-	for i := 0; i < dlen; i++ {
-		sortedIdx[i] = i
+//
+// floats64Equal will return true if `a` is equal to `b` or both of them are
+// NaN.
+//
+func floats64Equal(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+//
+// Floats64Frequencies return each distinct value in `d` with their count and
+// index of their first occurrence.
+//
+// All NaN are counted as one value, which is placed at the end when ordered
+// by value.
+//
+// See Frequencies for more information.
+//
+func Floats64Frequencies(d []float64, order FrequencyOrder) (
+	freqs []Frequency[float64],
+) {
+	return Floats64With(NaNPropagate).Frequencies(d, order)
+}
+
+//
+// Frequencies return each distinct value in `d` like Floats64Frequencies, but
+// NaN is placed at the beginning when ordered by value if the policy of `f` is
+// NaNFirst.
+// If the policy is NaNSkip, NaN is not included in the result.
+//
+func (f Floats64Ops) Frequencies(d []float64, order FrequencyOrder) (
+	freqs []Frequency[float64],
+) {
+	ft := newFreqTable(d)
+	if f.policy == NaNSkip {
+		ft.removeNaN()
 	}
 
-	Floats64InplaceMergesort(d, sortedIdx, 0, dlen, asc)
+	freqs = ft.freqs
 
-	return
+	sortFrequencies(freqs, order, f.compareAsc)
+
+	return freqs
+}
+
+//
+// Floats64Mode return the most frequent value in `d` and their count.
+//
+// All NaN are counted as one value.
+// On ModeSmallest and ModeLargest, NaN is ordered as larger than any other
+// values.
+//
+// See Mode for more information.
+//
+func Floats64Mode(d []float64, opts *ModeOptions) (
+	modes []float64, count int, ok bool,
+) {
+	return Floats64With(NaNPropagate).Mode(d, opts)
+}
+
+//
+// Mode return the most frequent value in `d` like Floats64Mode, but NaN is
+// ordered as smaller than any other values if the policy of `f` is NaNFirst.
+// If the policy is NaNSkip, NaN is ignored.
+//
+func (f Floats64Ops) Mode(d []float64, opts *ModeOptions) (
+	modes []float64, count int, ok bool,
+) {
+	ft := newFreqTable(d)
+	if f.policy == NaNSkip {
+		ft.removeNaN()
+	}
+
+	counts := make([]int, len(ft.freqs))
+	for x, f := range ft.freqs {
+		counts[x] = f.Count
+	}
+
+	modes, count = selectModes(ft.freqs, counts, opts, f.compareAsc)
+
+	return modes, count, len(modes) > 0
+}
+
+//
+// Floats64ModeWeighted return the value in `d` with the maximum total
+// weight.
+// The NaN in `d` is handled like in Floats64Mode.
+//
+// See ModeWeighted for more information.
+//
+func Floats64ModeWeighted(d, w []float64, opts *ModeOptions) (
+	modes []float64, weight float64, err error,
+) {
+	return Floats64With(NaNPropagate).ModeWeighted(d, w, opts)
+}
+
+//
+// ModeWeighted return the value in `d` with the maximum total weight like
+// Floats64ModeWeighted, with the NaN in `d` handled like in Mode of `f`.
+//
+func (f Floats64Ops) ModeWeighted(d, w []float64, opts *ModeOptions) (
+	modes []float64, weight float64, err error,
+) {
+	const fn = "numerus: Floats64ModeWeighted"
+
+	ft, err := newWeightedFreqTable(fn, d, w)
+	if err != nil {
+		return nil, 0, err
+	}
+	if f.policy == NaNSkip {
+		ft.removeNaN()
+	}
+
+	modes, weight = selectModes(ft.freqs, ft.weights, opts,
+		f.compareAsc)
+
+	return modes, weight, nil
+}
+
+//
+// compareAsc compare two float in ascending order, with NaN placed
+// following the NaN policy in `f`.
+//
+func (f Floats64Ops) compareAsc(a, b float64) int {
+	return f.compare(a, b, true)
+}
+
+//
+// Floats64CountWeighted return the total weight of `class` in data, where
+// each value `d[x]` has weight `w[x]`.
+// If class is NaN, it will return the total weight of NaN in data.
+//
+// See CountWeighted for more information.
+//
+func Floats64CountWeighted(d, w []float64, class float64) (
+	total float64, err error,
+) {
+	return Floats64With(NaNPropagate).CountWeighted(d, w, class)
+}
+
+//
+// CountWeighted return the total weight of `class` in data like
+// Floats64CountWeighted, but if the policy of `f` is NaNSkip, the weight of NaN
+// is never counted.
+//
+func (f Floats64Ops) CountWeighted(d, w []float64, class float64) (
+	total float64, err error,
+) {
+	const fn = "numerus: Floats64CountWeighted"
+
+	totals, err := f.countsWeighted(fn, d, w, []float64{class})
+	if err != nil {
+		return 0, err
+	}
+	return totals[0], nil
+}
+
+//
+// Floats64CountsWeighted return the total weight of each class in data.
+// The NaN in classes is counted like in Floats64CountWeighted.
+//
+// See CountsWeighted for more information.
+//
+func Floats64CountsWeighted(d, w, classes []float64) (
+	totals []float64, err error,
+) {
+	return Floats64With(NaNPropagate).CountsWeighted(d, w, classes)
+}
+
+//
+// CountsWeighted return the total weight of each class in data, with the NaN
+// in classes counted like in CountWeighted of `f`.
+//
+func (f Floats64Ops) CountsWeighted(d, w, classes []float64) (
+	totals []float64, err error,
+) {
+	const fn = "numerus: Floats64CountsWeighted"
+
+	return f.countsWeighted(fn, d, w, classes)
+}
+
+//
+// Floats64MaxCountOfWeighted return the class with maximum total weight in
+// data.
+// The NaN in classes is counted like in Floats64CountWeighted.
+//
+// See MaxCountOfWeighted for more information.
+//
+func Floats64MaxCountOfWeighted(d, w, classes []float64) (
+	class float64, ok bool, err error,
+) {
+	return Floats64With(NaNPropagate).MaxCountOfWeighted(d, w, classes)
+}
+
+//
+// MaxCountOfWeighted return the class with maximum total weight in data, with
+// the NaN in classes counted like in CountWeighted of `f`.
+//
+func (f Floats64Ops) MaxCountOfWeighted(d, w, classes []float64) (
+	class float64, ok bool, err error,
+) {
+	const fn = "numerus: Floats64MaxCountOfWeighted"
+
+	totals, err := f.countsWeighted(fn, d, w, classes)
+	if err != nil {
+		return 0, false, err
+	}
+
+	class, ok = maxCountOfWeighted(d, classes, totals)
+
+	return class, ok, nil
+}
+
+func (f Floats64Ops) countsWeighted(fn string, d, w, classes []float64) (
+	totals []float64, err error,
+) {
+	ft, err := newWeightedFreqTable(fn, d, w)
+	if err != nil {
+		return nil, err
+	}
+
+	totals = ft.weightsOf(classes)
+	if ft.nan < 0 || f.policy == NaNSkip {
+		return totals, nil
+	}
+	for x, c := range classes {
+		if math.IsNaN(c) {
+			totals[x] = ft.weights[ft.nan]
+		}
+	}
+	return totals, nil
+}
+
+//
+// Floats64CrossTab count the occurrence of each pair of class in `rows` and
+// `cols`.
+//
+// NaN is equal to NaN and placed at the end of the discovered classes.
+//
+// See CrossTab for more information.
+//
+func Floats64CrossTab(rows, cols, rowClasses, colClasses []float64) (
+	ct *CrossTable[float64], err error,
+) {
+	return Floats64With(NaNPropagate).CrossTab(rows, cols, rowClasses,
+		colClasses)
+}
+
+//
+// CrossTab count each pair of class like Floats64CrossTab, but NaN is placed at
+// the beginning of the discovered classes if the policy of `f` is NaNFirst.
+// If the policy is NaNSkip, pair that contains NaN is not counted.
+//
+func (f Floats64Ops) CrossTab(rows, cols, rowClasses, colClasses []float64) (
+	ct *CrossTable[float64], err error,
+) {
+	const fn = "numerus: Floats64CrossTab"
+
+	return crossTab(fn, rows, cols, rowClasses, colClasses,
+		f.compareAsc, f.policy == NaNSkip)
 }
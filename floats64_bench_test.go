@@ -18,3 +18,15 @@ func BenchmarkFloats64InplaceMergesort(b *testing.B) {
 			true)
 	}
 }
+
+func BenchmarkFloats64IndirectRadixSort(b *testing.B) {
+	d := make([]float64, len(inSorts[6]))
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(d, inSorts[6])
+		b.StartTimer()
+
+		numerus.Floats64IndirectRadixSort(d, true)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"fmt"
+	"slices"
+)
+
+//
+// Frequency contains the distinct value in data and number of its
+// occurrence.
+//
+type Frequency[T Number] struct {
+	Value T
+	Count int
+
+	// First is the index of the first occurrence of Value in data.
+	First int
+}
+
+//
+// FrequencyOrder define the order of result from Frequencies.
+//
+type FrequencyOrder int
+
+// List of frequency order.
+const (
+	// FrequencyByFirst order the values by their first occurrence in
+	// data.
+	FrequencyByFirst FrequencyOrder = iota
+
+	// FrequencyByValue order the values in ascending order.
+	FrequencyByValue
+
+	// FrequencyByCount order the values by their count in descending
+	// order; values with the same count are ordered by their first
+	// occurrence.
+	FrequencyByCount
+)
+
+//
+// Frequencies return each distinct value in `d` with their count and index
+// of their first occurrence, by scanning the data once.
+//
+// If `d` contains NaN, all of them are counted as one value, which is placed
+// at the end when ordered by value.
+//
+// Example, given data [3 1 3 2 3 1] it will return
+// [{3 3 0} {1 2 1} {2 1 3}] ordered by first occurrence or by count, and
+// [{1 2 1} {2 1 3} {3 3 0}] ordered by value.
+//
+func Frequencies[T Number](d []T, order FrequencyOrder) (
+	freqs []Frequency[T],
+) {
+	freqs = newFreqTable(d).freqs
+	sortFrequencies(freqs, order, compareNaNLast[T])
+	return freqs
+}
+
+//
+// freqTable contains the frequency of each distinct value in data, and the
+// position of each value in the table for fast lookup.
+//
+type freqTable[T Number] struct {
+	freqs []Frequency[T]
+
+	// weights contains the total weight of each value in freqs, only if
+	// the table is created using newWeightedFreqTable.
+	weights []float64
+
+	pos map[T]int
+
+	// nan is the position of NaN in freqs, or -1 if data does not
+	// contains NaN.
+	nan int
+}
+
+func newFreqTable[T Number](d []T) (ft *freqTable[T]) {
+	ft = &freqTable[T]{
+		pos: make(map[T]int),
+		nan: -1,
+	}
+	for x, v := range d {
+		p := ft.add(x, v)
+		ft.freqs[p].Count++
+	}
+	return ft
+}
+
+//
+// newWeightedFreqTable create the frequency table where each value `d[x]`
+// has weight `w[x]`.
+// It will return an error if the length of `d` and `w` is not equal, or one
+// of the weight is negative or NaN.
+//
+func newWeightedFreqTable[T Number](fn string, d []T, w []float64) (
+	ft *freqTable[T], err error,
+) {
+	if len(d) != len(w) {
+		return nil, &LengthError{Func: fn, Len: len(w), Exp: len(d)}
+	}
+	for x, v := range w {
+		if !(v >= 0) {
+			return nil, fmt.Errorf("%s: index %d: %w: %v", fn, x,
+				ErrNegativeWeight, v)
+		}
+	}
+
+	ft = &freqTable[T]{
+		pos: make(map[T]int),
+		nan: -1,
+	}
+	for x, v := range d {
+		p := ft.add(x, v)
+		if p == len(ft.weights) {
+			ft.weights = append(ft.weights, 0)
+		}
+		ft.freqs[p].Count++
+		ft.weights[p] += w[x]
+	}
+	return ft, nil
+}
+
+//
+// add the value `v` at index `x` in data into table, if its not exist, and
+// return its position in table.
+//
+func (ft *freqTable[T]) add(x int, v T) (p int) {
+	var ok bool
+
+	if isNaN(v) {
+		p, ok = ft.nan, ft.nan >= 0
+		if !ok {
+			ft.nan = len(ft.freqs)
+		}
+	} else {
+		p, ok = ft.pos[v]
+		if !ok {
+			ft.pos[v] = len(ft.freqs)
+		}
+	}
+	if ok {
+		return p
+	}
+
+	ft.freqs = append(ft.freqs, Frequency[T]{
+		Value: v,
+		First: x,
+	})
+	return len(ft.freqs) - 1
+}
+
+//
+// removeNaN remove the NaN from table.
+//
+func (ft *freqTable[T]) removeNaN() {
+	if ft.nan < 0 {
+		return
+	}
+	ft.freqs = append(ft.freqs[:ft.nan], ft.freqs[ft.nan+1:]...)
+	if ft.weights != nil {
+		ft.weights = append(ft.weights[:ft.nan],
+			ft.weights[ft.nan+1:]...)
+	}
+	for v, p := range ft.pos {
+		if p > ft.nan {
+			ft.pos[v] = p - 1
+		}
+	}
+	ft.nan = -1
+}
+
+//
+// count return the number of value `v` in table.
+// Like the Count function, NaN is not equal to any value, so the count of
+// NaN is always zero.
+//
+func (ft *freqTable[T]) count(v T) int {
+	p, ok := ft.pos[v]
+	if !ok {
+		return 0
+	}
+	return ft.freqs[p].Count
+}
+
+//
+// counts return the number of each value in `classes` in table.
+//
+func (ft *freqTable[T]) counts(classes []T) (counts []int) {
+	counts = make([]int, len(classes))
+	for x, c := range classes {
+		counts[x] = ft.count(c)
+	}
+	return counts
+}
+
+//
+// weightsOf return the total weight of each value in `classes` in weighted
+// table.
+// Like in count, the weight of NaN is always zero.
+//
+func (ft *freqTable[T]) weightsOf(classes []T) (weights []float64) {
+	weights = make([]float64, len(classes))
+	for x, c := range classes {
+		if p, ok := ft.pos[c]; ok {
+			weights[x] = ft.weights[p]
+		}
+	}
+	return weights
+}
+
+//
+// sortFrequencies sort the frequencies by `order` using comparison function
+// `cmp` to compare the values.
+// The frequencies is already ordered by their first occurrence.
+//
+func sortFrequencies[T Number](freqs []Frequency[T], order FrequencyOrder,
+	cmp func(a, b T) int,
+) {
+	switch order {
+	case FrequencyByValue:
+		slices.SortStableFunc(freqs, func(a, b Frequency[T]) int {
+			return cmp(a.Value, b.Value)
+		})
+	case FrequencyByCount:
+		slices.SortStableFunc(freqs, func(a, b Frequency[T]) int {
+			return b.Count - a.Count
+		})
+	}
+}
+
+//
+// isNaN will return true if `v` is not equal to itself, which is only true
+// if `v` is floating point NaN.
+//
+func isNaN[T Number](v T) bool {
+	return v != v
+}
@@ -0,0 +1,91 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestFrequencies(t *testing.T) {
+	d := []int{3, 1, 3, 2, 3, 1, 2, 0}
+
+	cases := []struct {
+		order numerus.FrequencyOrder
+		exp   []numerus.Frequency[int]
+	}{{
+		order: numerus.FrequencyByFirst,
+		exp: []numerus.Frequency[int]{
+			{Value: 3, Count: 3, First: 0},
+			{Value: 1, Count: 2, First: 1},
+			{Value: 2, Count: 2, First: 3},
+			{Value: 0, Count: 1, First: 7},
+		},
+	}, {
+		order: numerus.FrequencyByValue,
+		exp: []numerus.Frequency[int]{
+			{Value: 0, Count: 1, First: 7},
+			{Value: 1, Count: 2, First: 1},
+			{Value: 2, Count: 2, First: 3},
+			{Value: 3, Count: 3, First: 0},
+		},
+	}, {
+		order: numerus.FrequencyByCount,
+		exp: []numerus.Frequency[int]{
+			{Value: 3, Count: 3, First: 0},
+			{Value: 1, Count: 2, First: 1},
+			{Value: 2, Count: 2, First: 3},
+			{Value: 0, Count: 1, First: 7},
+		},
+	}}
+
+	for _, c := range cases {
+		got := numerus.IntsFrequencies(d, c.order)
+
+		assert(t, c.exp, got, true)
+	}
+
+	assert(t, 0, len(numerus.Ints64Frequencies(nil,
+		numerus.FrequencyByValue)), true)
+}
+
+func TestFrequencies_NaN(t *testing.T) {
+	d := []float32{2, float32(nan), 1, float32(nan)}
+
+	got := numerus.Frequencies(d, numerus.FrequencyByValue)
+
+	assert(t, "[{1 1 2} {2 1 0} {NaN 2 1}]", fmt.Sprint(got), true)
+
+	// Like Count, the NaN is not equal to any class.
+	assert(t, []int{0, 1}, numerus.Counts(d, []float32{float32(nan), 2}),
+		true)
+}
+
+func TestFloats64Frequencies(t *testing.T) {
+	cases := []struct {
+		policy numerus.NaNPolicy
+		exp    string
+	}{{
+		policy: numerus.NaNPropagate,
+		exp: "[{-Inf 1 6} {-1 1 3} {-0 2 4} {3 1 0} {+Inf 1 2} " +
+			"{NaN 2 1}]",
+	}, {
+		policy: numerus.NaNFirst,
+		exp: "[{NaN 2 1} {-Inf 1 6} {-1 1 3} {-0 2 4} {3 1 0} " +
+			"{+Inf 1 2}]",
+	}, {
+		policy: numerus.NaNSkip,
+		exp:    "[{-Inf 1 6} {-1 1 3} {-0 2 4} {3 1 0} {+Inf 1 2}]",
+	}}
+
+	for _, c := range cases {
+		f := numerus.Floats64With(c.policy)
+
+		got := f.Frequencies(dFloats64NaN, numerus.FrequencyByValue)
+
+		assert(t, c.exp, fmt.Sprint(got), true)
+	}
+}
@@ -0,0 +1,1416 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math"
+	"sort"
+	"testing"
+)
+
+//
+// fuzzSeeds is the seed corpus for all fuzz targets.
+//
+var fuzzSeeds = [][]byte{
+	{},
+	{1},
+	{2, 1},
+	{3, 1, 2, 1, 3, 3, 0, 0},
+	{0xfc, 1, 0xfd, 0xff, 0, 0xfe, 0xfc, 2},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 0xff, 0xfe, 0xfd, 0xfc},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+}
+
+//
+// fuzzNumbers convert each byte in data into signed integer and then into
+// type T, so the fuzzer produce many negative and equal elements.
+// On unsigned type, the negative value become the large one.
+//
+func fuzzNumbers[T numerus.Number](data []byte) (d []T) {
+	d = make([]T, len(data))
+	for x, b := range data {
+		d[x] = T(int8(b))
+	}
+	return d
+}
+
+//
+// fuzzFloat convert byte into float, where the last four values are mapped
+// to NaN, positive infinity, negative infinity, and negative zero.
+//
+func fuzzFloat(b byte) float64 {
+	switch b {
+	case 0xfc:
+		return nan
+	case 0xfd:
+		return posInf
+	case 0xfe:
+		return negInf
+	case 0xff:
+		return negZero
+	}
+	return float64(int8(b)) / 4
+}
+
+func fuzzFloats64(data []byte) (d []float64) {
+	d = make([]float64, len(data))
+	for x, b := range data {
+		d[x] = fuzzFloat(b)
+	}
+	return d
+}
+
+//
+// fuzzEqual will return true if `a` is equal to `b` or both of them are
+// NaN.
+//
+func fuzzEqual[T numerus.Number](a, b T) bool {
+	return a == b || (a != a && b != b)
+}
+
+//
+// fuzzCompare compare two float following the NaN policy, where NaN is equal
+// to NaN.
+//
+func fuzzCompare(a, b float64, asc bool, policy numerus.NaNPolicy) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || bNaN:
+		c := 1
+		if policy == numerus.NaNFirst {
+			c = -1
+		}
+		if bNaN {
+			c = -c
+		}
+		return c
+	case a == b:
+		return 0
+	case (a < b) == asc:
+		return -1
+	}
+	return 1
+}
+
+//
+// fuzzExpSorted return the copy of `in` that is sorted using sort.Slice.
+// The `in` must not contains NaN.
+//
+func fuzzExpSorted[T numerus.Number](in []T, asc bool) (exp []T) {
+	exp = make([]T, len(in))
+	copy(exp, in)
+	sort.Slice(exp, func(x, y int) bool {
+		if asc {
+			return exp[x] < exp[y]
+		}
+		return exp[x] > exp[y]
+	})
+	return exp
+}
+
+//
+// fuzzExpFloats64 return the expected result of sorting `in` using
+// sort.Float64s, with NaN moved following the NaN policy.
+//
+func fuzzExpFloats64(in []float64, asc bool, policy numerus.NaNPolicy) (
+	exp []float64,
+) {
+	var vals []float64
+	nans := 0
+	for _, v := range in {
+		if math.IsNaN(v) {
+			nans++
+			continue
+		}
+		vals = append(vals, v)
+	}
+
+	sort.Float64s(vals)
+	if !asc {
+		for x, y := 0, len(vals)-1; x < y; x, y = x+1, y-1 {
+			vals[x], vals[y] = vals[y], vals[x]
+		}
+	}
+
+	for ; nans > 0; nans-- {
+		if policy == numerus.NaNFirst {
+			vals = append([]float64{nan}, vals...)
+		} else {
+			vals = append(vals, nan)
+		}
+	}
+	return vals
+}
+
+//
+// fuzzCheckSorted check that the sorted `d` is equal to `exp` and the index
+// `ids` is a permutation that map `d` back to the input `in`.
+// If `stable` is true, equal elements must keep their original order.
+//
+func fuzzCheckSorted[T numerus.Number](t *testing.T, name string,
+	in, exp, d []T, ids []int, stable bool,
+) {
+	t.Helper()
+
+	for x := range exp {
+		if !fuzzEqual(exp[x], d[x]) {
+			t.Fatalf("%s: got %v, want %v", name, d, exp)
+		}
+	}
+	if ids == nil {
+		return
+	}
+	if !numerus.PermIsValid(ids) {
+		t.Fatalf("%s: invalid permutation %v", name, ids)
+	}
+	for x, id := range ids {
+		if !fuzzEqual(in[id], d[x]) {
+			t.Fatalf("%s: index %v does not map %v to input %v",
+				name, ids, d, in)
+		}
+		if stable && x > 0 && fuzzEqual(d[x-1], d[x]) && ids[x-1] > id {
+			t.Fatalf("%s: unstable index %v of %v", name, ids, d)
+		}
+	}
+}
+
+//
+// fuzzSort define the sort function that is checked by fuzzRunSorts.
+// The `fn` sort the `d` in place and return the index that map the sorted
+// `d` back to the input.
+//
+type fuzzSort[T numerus.Number] struct {
+	name   string
+	stable bool
+	fn     func(d []T, ids []int, asc bool) []int
+}
+
+//
+// fuzzRunSorts run each sort function in `sorts` on the copy of `in` and
+// check their result against sort.Slice.
+//
+func fuzzRunSorts[T numerus.Number](t *testing.T, sorts []fuzzSort[T],
+	in []T, asc bool,
+) {
+	t.Helper()
+
+	var zero T
+
+	exp := fuzzExpSorted(in, asc)
+
+	for _, s := range sorts {
+		name := fmt.Sprintf("%s[%T]", s.name, zero)
+
+		d := make([]T, len(in))
+		copy(d, in)
+		ids := make([]int, len(in))
+		for x := range ids {
+			ids[x] = x
+		}
+
+		ids = s.fn(d, ids, asc)
+
+		fuzzCheckSorted(t, name, in, exp, d, ids, s.stable)
+		if !numerus.IsSorted(d, asc, false) {
+			t.Fatalf("%s: IsSorted: %v", name, d)
+		}
+	}
+}
+
+//
+// fuzzNumberSorts return the generic sort functions for type T.
+//
+func fuzzNumberSorts[T numerus.Number]() []fuzzSort[T] {
+	less := func(d []T, asc bool) func(i, j int) bool {
+		return func(i, j int) bool {
+			if asc {
+				return d[i] < d[j]
+			}
+			return d[i] > d[j]
+		}
+	}
+
+	return []fuzzSort[T]{{
+		"InsertionSort", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.InsertionSort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"InplaceMergesort", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.InplaceMergesort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"InplaceMergesortParallel", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.InplaceMergesortParallel(d, ids, 0, len(d),
+				asc, 3)
+			return ids
+		},
+	}, {
+		"IndirectSort", true,
+		func(d []T, _ []int, asc bool) []int {
+			return numerus.IndirectSort(d, asc)
+		},
+	}, {
+		"Argsort", true,
+		func(d []T, _ []int, asc bool) []int {
+			ids := numerus.Argsort(d, asc)
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"InplaceMergesortFunc", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.InplaceMergesortFunc(ids, 0, len(ids),
+				less(d, asc))
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"IndirectSortFunc", true,
+		func(d []T, _ []int, asc bool) []int {
+			ids := numerus.IndirectSortFunc(len(d), less(d, asc))
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"SortByKey", true,
+		func(d []T, _ []int, asc bool) []int {
+			return numerus.SortByKey(d, func(v T) T {
+				return v
+			}, asc)
+		},
+	}, {
+		"Sort/auto", false,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.Sort(d, ids, 0, len(d), asc, nil)
+			return ids
+		},
+	}, {
+		"Sort/pdqsort", false,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortPdqsort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}, {
+		"Sort/timsort", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortTimsort,
+				})
+			return ids
+		},
+	}, {
+		"Sort/mergesort", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortMergesort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}}
+}
+
+//
+// fuzzIntegerSorts return the generic sort functions for type T, including
+// the radix sort.
+//
+func fuzzIntegerSorts[T numerus.Integer]() []fuzzSort[T] {
+	return append(fuzzNumberSorts[T](), fuzzSort[T]{
+		"RadixSort", true,
+		func(d []T, ids []int, asc bool) []int {
+			numerus.RadixSort(d, ids, asc)
+			return ids
+		},
+	}, fuzzSort[T]{
+		"IndirectRadixSort", true,
+		func(d []T, _ []int, asc bool) []int {
+			return numerus.IndirectRadixSort(d, asc)
+		},
+	})
+}
+
+func FuzzIntsSort(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, true)
+		f.Add(seed, false)
+	}
+
+	sorts := []fuzzSort[int]{{
+		"IntsInsertionSort", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsInsertionSort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"IntsInplaceMergesort", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsInplaceMergesort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"IntsInplaceMergesortParallel", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsInplaceMergesortParallel(d, ids, 0, len(d),
+				asc, 3)
+			return ids
+		},
+	}, {
+		"IntsIndirectSort", true,
+		func(d, _ []int, asc bool) []int {
+			return numerus.IntsIndirectSort(d, asc)
+		},
+	}, {
+		"IntsRadixSort", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsRadixSort(d, ids, asc)
+			return ids
+		},
+	}, {
+		"IntsIndirectRadixSort", true,
+		func(d, _ []int, asc bool) []int {
+			return numerus.IntsIndirectRadixSort(d, asc)
+		},
+	}, {
+		"IntsArgsort", true,
+		func(d, _ []int, asc bool) []int {
+			ids := numerus.IntsArgsort(d, asc)
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"IntsSort/auto", false,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsSort(d, ids, 0, len(d), asc, nil)
+			return ids
+		},
+	}, {
+		"IntsSort/pdqsort", false,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsSort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortPdqsort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}, {
+		"IntsSort/timsort", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsSort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortTimsort,
+				})
+			return ids
+		},
+	}, {
+		"IntsSort/mergesort", true,
+		func(d, ids []int, asc bool) []int {
+			numerus.IntsSort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortMergesort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}}
+
+	f.Fuzz(func(t *testing.T, data []byte, asc bool) {
+		fuzzRunSorts(t, sorts, fuzzNumbers[int](data), asc)
+	})
+}
+
+func FuzzInts64Sort(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, true)
+		f.Add(seed, false)
+	}
+
+	sorts := []fuzzSort[int64]{{
+		"Ints64InsertionSort", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64InsertionSort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"Ints64InplaceMergesort", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64InplaceMergesort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"Ints64InplaceMergesortParallel", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64InplaceMergesortParallel(d, ids, 0,
+				len(d), asc, 3)
+			return ids
+		},
+	}, {
+		"Ints64IndirectSort", true,
+		func(d []int64, _ []int, asc bool) []int {
+			return numerus.Ints64IndirectSort(d, asc)
+		},
+	}, {
+		"Ints64RadixSort", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64RadixSort(d, ids, asc)
+			return ids
+		},
+	}, {
+		"Ints64IndirectRadixSort", true,
+		func(d []int64, _ []int, asc bool) []int {
+			return numerus.Ints64IndirectRadixSort(d, asc)
+		},
+	}, {
+		"Ints64Argsort", true,
+		func(d []int64, _ []int, asc bool) []int {
+			ids := numerus.Ints64Argsort(d, asc)
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"Ints64Sort/auto", false,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64Sort(d, ids, 0, len(d), asc, nil)
+			return ids
+		},
+	}, {
+		"Ints64Sort/pdqsort", false,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortPdqsort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}, {
+		"Ints64Sort/timsort", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortTimsort,
+				})
+			return ids
+		},
+	}, {
+		"Ints64Sort/mergesort", true,
+		func(d []int64, ids []int, asc bool) []int {
+			numerus.Ints64Sort(d, ids, 0, len(d), asc,
+				&numerus.SortOptions{
+					Algorithm: numerus.SortMergesort,
+					Threshold: 1,
+				})
+			return ids
+		},
+	}}
+
+	f.Fuzz(func(t *testing.T, data []byte, asc bool) {
+		fuzzRunSorts(t, sorts, fuzzNumbers[int64](data), asc)
+	})
+}
+
+//
+// FuzzSort check the generic sort functions on the other types than int and
+// int64, including unsigned integer and float32 without NaN.
+//
+func FuzzSort(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, true)
+		f.Add(seed, false)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, asc bool) {
+		fuzzRunSorts(t, fuzzIntegerSorts[int8](),
+			fuzzNumbers[int8](data), asc)
+		fuzzRunSorts(t, fuzzIntegerSorts[uint16](),
+			fuzzNumbers[uint16](data), asc)
+		fuzzRunSorts(t, fuzzIntegerSorts[int32](),
+			fuzzNumbers[int32](data), asc)
+		fuzzRunSorts(t, fuzzIntegerSorts[uint64](),
+			fuzzNumbers[uint64](data), asc)
+		fuzzRunSorts(t, fuzzNumberSorts[float32](),
+			fuzzNumbers[float32](data), asc)
+	})
+}
+
+func FuzzFloats64Sort(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, true, policy)
+			f.Add(seed, false, policy)
+		}
+	}
+
+	// Radix sort place -0 before +0 in ascending order, so it is not
+	// checked for stability.
+	sorts := []struct {
+		name   string
+		stable bool
+		fn     func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool) []int
+	}{{
+		"Floats64InsertionSort", true,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.InsertionSort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"Floats64InplaceMergesort", true,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.InplaceMergesort(d, ids, 0, len(d), asc)
+			return ids
+		},
+	}, {
+		"Floats64InplaceMergesortParallel", true,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.InplaceMergesortParallel(d, ids, 0, len(d), asc, 3)
+			return ids
+		},
+	}, {
+		"Floats64IndirectSort", true,
+		func(ops numerus.Floats64Ops, d []float64, _ []int,
+			asc bool,
+		) []int {
+			return ops.IndirectSort(d, asc)
+		},
+	}, {
+		"Floats64RadixSort", false,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.RadixSort(d, ids, asc)
+			return ids
+		},
+	}, {
+		"Floats64IndirectRadixSort", false,
+		func(ops numerus.Floats64Ops, d []float64, _ []int,
+			asc bool,
+		) []int {
+			return ops.IndirectRadixSort(d, asc)
+		},
+	}, {
+		"Floats64Argsort", true,
+		func(ops numerus.Floats64Ops, d []float64, _ []int,
+			asc bool,
+		) []int {
+			ids := ops.Argsort(d, asc)
+			numerus.PermApply(d, ids)
+			return ids
+		},
+	}, {
+		"SortByKeyWith", true,
+		func(ops numerus.Floats64Ops, d []float64, _ []int,
+			asc bool,
+		) []int {
+			key := func(v float64) float64 { return v }
+			return numerus.SortByKeyWith(d, key, asc, ops.Policy())
+		},
+	}, {
+		"Floats64Sort/auto", false,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.Sort(d, ids, 0, len(d), asc, nil)
+			return ids
+		},
+	}, {
+		"Floats64Sort/pdqsort", false,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.Sort(d, ids, 0, len(d), asc, &numerus.SortOptions{
+				Algorithm: numerus.SortPdqsort,
+				Threshold: 1,
+			})
+			return ids
+		},
+	}, {
+		"Floats64Sort/timsort", true,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.Sort(d, ids, 0, len(d), asc, &numerus.SortOptions{
+				Algorithm: numerus.SortTimsort,
+			})
+			return ids
+		},
+	}, {
+		"Floats64Sort/mergesort", true,
+		func(ops numerus.Floats64Ops, d []float64, ids []int,
+			asc bool,
+		) []int {
+			ops.Sort(d, ids, 0, len(d), asc, &numerus.SortOptions{
+				Algorithm: numerus.SortMergesort,
+				Threshold: 1,
+			})
+			return ids
+		},
+	}}
+
+	f.Fuzz(func(t *testing.T, data []byte, asc bool, policy uint8) {
+		in := fuzzFloats64(data)
+		p := numerus.NaNPolicy(policy % 4)
+
+		ops := numerus.Floats64With(p)
+
+		exp := fuzzExpFloats64(in, asc, p)
+
+		for _, s := range sorts {
+			d := make([]float64, len(in))
+			copy(d, in)
+			ids := make([]int, len(in))
+			for x := range ids {
+				ids[x] = x
+			}
+
+			ids = s.fn(ops, d, ids, asc)
+
+			fuzzCheckSorted(t, s.name, in, exp, d, ids,
+				s.stable)
+			if !ops.IsSorted(d, asc, false) {
+				t.Fatalf("%s: Floats64IsSorted: %v",
+					s.name, d)
+			}
+		}
+	})
+}
+
+//
+// fuzzShards split `in` into `n` slices, where element `x` is placed in
+// slice x%n, and then sort each of them using function `sortFn`.
+//
+func fuzzShards[T numerus.Number](in []T, n int, sortFn func([]T) []T) (
+	ds [][]T,
+) {
+	ds = make([][]T, n)
+	for x, v := range in {
+		ds[x%n] = append(ds[x%n], v)
+	}
+	for x := range ds {
+		ds[x] = sortFn(ds[x])
+	}
+	return ds
+}
+
+//
+// fuzzCheckMerge check that the merged `out` is equal to `exp`, each element
+// in `out` come from ds[src[x]][ids[x]], and equal elements are ordered by
+// their slice index and then by their index in slice.
+//
+func fuzzCheckMerge[T numerus.Number](t *testing.T, name string, ds [][]T,
+	exp, out []T, src, ids []int,
+) {
+	t.Helper()
+
+	if len(out) != len(exp) || len(src) != len(exp) ||
+		len(ids) != len(exp) {
+		t.Fatalf("%s: got %v %v %v, want %v", name, out, src, ids,
+			exp)
+	}
+	for x := range out {
+		if !fuzzEqual(exp[x], out[x]) {
+			t.Fatalf("%s: got %v, want %v", name, out, exp)
+		}
+		if !fuzzEqual(ds[src[x]][ids[x]], out[x]) {
+			t.Fatalf("%s: src %v and ids %v does not map %v to %v",
+				name, src, ids, out, ds)
+		}
+		if x == 0 || !fuzzEqual(out[x-1], out[x]) {
+			continue
+		}
+		if src[x-1] > src[x] ||
+			(src[x-1] == src[x] && ids[x-1] > ids[x]) {
+			t.Fatalf("%s: unstable src %v and ids %v of %v",
+				name, src, ids, out)
+		}
+	}
+}
+
+func FuzzMerge(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, uint8(3), true, policy)
+			f.Add(seed, uint8(1), false, policy)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, shards uint8, asc bool,
+		policy uint8,
+	) {
+		n := int(shards%4) + 1
+
+		ints := fuzzNumbers[int](data)
+		ds := fuzzShards(ints, n, func(d []int) []int {
+			return fuzzExpSorted(d, asc)
+		})
+		out, src, idx := numerus.IntsMerge(asc, ds...)
+		fuzzCheckMerge(t, "IntsMerge", ds, fuzzExpSorted(ints, asc),
+			out, src, idx)
+
+		ints64 := fuzzNumbers[int64](data)
+		ds64 := fuzzShards(ints64, n, func(d []int64) []int64 {
+			return fuzzExpSorted(d, asc)
+		})
+		out64, src, idx := numerus.Ints64Merge(asc, ds64...)
+		fuzzCheckMerge(t, "Ints64Merge", ds64,
+			fuzzExpSorted(ints64, asc), out64, src, idx)
+
+		uints := fuzzNumbers[uint16](data)
+		uds := fuzzShards(uints, n, func(d []uint16) []uint16 {
+			return fuzzExpSorted(d, asc)
+		})
+		outu, src, idx := numerus.Merge(asc, uds...)
+		fuzzCheckMerge(t, "Merge[uint16]", uds,
+			fuzzExpSorted(uints, asc), outu, src, idx)
+
+		floats := fuzzFloats64(data)
+		p := numerus.NaNPolicy(policy % 4)
+		fds := fuzzShards(floats, n, func(d []float64) []float64 {
+			return fuzzExpFloats64(d, asc, p)
+		})
+		outf, src, idx := numerus.Floats64With(p).Merge(asc, fds...)
+		fuzzCheckMerge(t, "Floats64Merge", fds,
+			fuzzExpFloats64(floats, asc, p), outf, src, idx)
+	})
+}
+
+func FuzzMultiIndirectSort(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, true, false, policy)
+			f.Add(seed, false, true, policy)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, asc1, asc2 bool,
+		policy uint8,
+	) {
+		n := len(data)
+		p := numerus.NaNPolicy(policy % 4)
+
+		var (
+			colA = make([]int, n)
+			colB = make([]float64, n)
+			colC = make([]int64, n)
+		)
+		for x, b := range data {
+			colA[x] = int(int8(b)) % 3
+			colB[x] = fuzzFloat(data[n-1-x])
+			colC[x] = int64(int8(b)) / 16
+		}
+
+		got, err := numerus.Floats64With(p).MultiIndirectSort(
+			numerus.SortKey{Column: colA, Asc: asc1},
+			numerus.SortKey{Column: colB, Asc: asc2},
+			numerus.SortKey{Column: colC, Asc: asc1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := make([]int, n)
+		for x := range exp {
+			exp[x] = x
+		}
+		sort.SliceStable(exp, func(x, y int) bool {
+			a, b := exp[x], exp[y]
+			if colA[a] != colA[b] {
+				return (colA[a] < colA[b]) == asc1
+			}
+			c := fuzzCompare(colB[a], colB[b], asc2, p)
+			if c != 0 {
+				return c < 0
+			}
+			if colC[a] != colC[b] {
+				return (colC[a] < colC[b]) == asc1
+			}
+			return false
+		})
+
+		if len(got) != n {
+			t.Fatalf("MultiIndirectSort: got %v, want %v", got,
+				exp)
+		}
+		for x := range exp {
+			if exp[x] != got[x] {
+				t.Fatalf("MultiIndirectSort: got %v, want %v",
+					got, exp)
+			}
+		}
+	})
+}
+
+//
+// fuzzCheckTopK check the result of TopK or BottomK against the first `k`
+// index of `in` that is sorted stable using `before`, after removing the
+// value that return true in `skip`.
+//
+func fuzzCheckTopK[T numerus.Number](t *testing.T, name string, in []T,
+	k int, vals []T, ids []int, before func(a, b T) bool,
+	skip func(T) bool,
+) {
+	t.Helper()
+
+	exp := make([]int, 0, len(in))
+	for x, v := range in {
+		if skip == nil || !skip(v) {
+			exp = append(exp, x)
+		}
+	}
+	sort.SliceStable(exp, func(x, y int) bool {
+		return before(in[exp[x]], in[exp[y]])
+	})
+	if k <= 0 {
+		exp = nil
+	} else if k < len(exp) {
+		exp = exp[:k]
+	}
+
+	if len(vals) != len(exp) || len(ids) != len(exp) {
+		t.Fatalf("%s: got %v %v, want index %v", name, vals, ids, exp)
+	}
+	for x, id := range exp {
+		if ids[x] != id || !fuzzEqual(in[id], vals[x]) {
+			t.Fatalf("%s: got %v %v, want index %v", name, vals,
+				ids, exp)
+		}
+	}
+}
+
+func FuzzTopK(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, int8(3), policy)
+		}
+		f.Add(seed, int8(0), uint8(0))
+		f.Add(seed, int8(100), uint8(0))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, k8 int8, policy uint8) {
+		k := int(k8)
+
+		ints := fuzzNumbers[int](data)
+		vals, ids := numerus.IntsTopK(ints, k)
+		fuzzCheckTopK(t, "IntsTopK", ints, k, vals, ids,
+			fuzzGreater[int], nil)
+		vals, ids = numerus.IntsBottomK(ints, k)
+		fuzzCheckTopK(t, "IntsBottomK", ints, k, vals, ids,
+			fuzzLess[int], nil)
+
+		ints64 := fuzzNumbers[int64](data)
+		vals64, ids := numerus.Ints64TopK(ints64, k)
+		fuzzCheckTopK(t, "Ints64TopK", ints64, k, vals64, ids,
+			fuzzGreater[int64], nil)
+		vals64, ids = numerus.Ints64BottomK(ints64, k)
+		fuzzCheckTopK(t, "Ints64BottomK", ints64, k, vals64, ids,
+			fuzzLess[int64], nil)
+
+		uints := fuzzNumbers[uint16](data)
+		valsu, ids := numerus.TopK(uints, k)
+		fuzzCheckTopK(t, "TopK[uint16]", uints, k, valsu, ids,
+			fuzzGreater[uint16], nil)
+		valsu, ids = numerus.BottomK(uints, k)
+		fuzzCheckTopK(t, "BottomK[uint16]", uints, k, valsu, ids,
+			fuzzLess[uint16], nil)
+
+		floats := fuzzFloats64(data)
+		p := numerus.NaNPolicy(policy % 4)
+		ops := numerus.Floats64With(p)
+
+		greater, less := fuzzGreater[float64], fuzzLess[float64]
+		skip := math.IsNaN
+		if p == numerus.NaNPropagate {
+			// NaN is placed before any other values.
+			nanBefore := func(a, b float64) bool {
+				return math.IsNaN(a) && !math.IsNaN(b)
+			}
+			greater = func(a, b float64) bool {
+				return a > b || nanBefore(a, b)
+			}
+			less = func(a, b float64) bool {
+				return a < b || nanBefore(a, b)
+			}
+			skip = nil
+		}
+
+		valsf, ids := ops.TopK(floats, k)
+		fuzzCheckTopK(t, "Floats64TopK", floats, k, valsf, ids,
+			greater, skip)
+		valsf, ids = ops.BottomK(floats, k)
+		fuzzCheckTopK(t, "Floats64BottomK", floats, k, valsf, ids,
+			less, skip)
+	})
+}
+
+func fuzzLess[T numerus.Number](a, b T) bool {
+	return a < b
+}
+
+func fuzzGreater[T numerus.Number](a, b T) bool {
+	return a > b
+}
+
+//
+// fuzzCheckSelect check the result of NthElement, Select, and Median on
+// data `in` that does not contains NaN.
+//
+func fuzzCheckSelect[T numerus.Number](t *testing.T, name string, in []T,
+	n int, nth func(d []T, idx []int, n int) (T, int, bool),
+	sel func(d []T, n int) (T, int, bool),
+	med func(d []T) (T, int, bool),
+) {
+	t.Helper()
+
+	exp := fuzzExpSorted(in, true)
+	expOK := n >= 0 && n < len(in)
+
+	d := make([]T, len(in))
+	copy(d, in)
+	ids := numerus.IntCreateSeq(0, len(in)-1)
+
+	v, i, ok := nth(d, ids, n)
+	if ok != expOK {
+		t.Fatalf("%sNthElement: n %d: got ok %v", name, n, ok)
+	}
+	if ok {
+		if v != exp[n] || d[n] != v || in[i] != v {
+			t.Fatalf("%sNthElement: n %d: got %v at %d, want %v",
+				name, n, v, i, exp[n])
+		}
+		for x := range d {
+			if in[ids[x]] != d[x] {
+				t.Fatalf("%sNthElement: index %v does not map"+
+					" %v to input %v", name, ids, d, in)
+			}
+			if (x < n && d[x] > v) || (x > n && d[x] < v) {
+				t.Fatalf("%sNthElement: n %d: not partitioned"+
+					" %v", name, n, d)
+			}
+		}
+	}
+
+	v, i, ok = sel(in, n)
+	if ok != expOK || (ok && (v != exp[n] || in[i] != v)) {
+		t.Fatalf("%sSelect: n %d: got %v at %d and %v", name, n, v,
+			i, ok)
+	}
+
+	m := (len(in) - 1) / 2
+	v, i, ok = med(in)
+	if ok != (len(in) > 0) || (ok && (v != exp[m] || in[i] != v)) {
+		t.Fatalf("%sMedian: got %v at %d and %v", name, v, i, ok)
+	}
+}
+
+func FuzzSelect(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, int8(0), policy)
+			f.Add(seed, int8(len(seed)/2), policy)
+			f.Add(seed, int8(len(seed)-1), policy)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, n8 int8, policy uint8) {
+		n := int(n8)
+
+		fuzzCheckSelect(t, "Ints", fuzzNumbers[int](data), n,
+			numerus.IntsNthElement, numerus.IntsSelect,
+			numerus.IntsMedian)
+		fuzzCheckSelect(t, "Ints64", fuzzNumbers[int64](data), n,
+			numerus.Ints64NthElement, numerus.Ints64Select,
+			numerus.Ints64Median)
+		fuzzCheckSelect(t, "[uint16]", fuzzNumbers[uint16](data), n,
+			numerus.NthElement[uint16], numerus.Select[uint16],
+			numerus.Median[uint16])
+		fuzzCheckSelect(t, "[float32]", fuzzNumbers[float32](data), n,
+			numerus.NthElement[float32], numerus.Select[float32],
+			numerus.Median[float32])
+
+		in := fuzzFloats64(data)
+		p := numerus.NaNPolicy(policy % 4)
+		ops := numerus.Floats64With(p)
+
+		exp := fuzzExpFloats64(in, true, p)
+
+		// The non-NaN values is placed in exp[lo:hi].
+		lo, hi := 0, len(exp)
+		for _, v := range in {
+			if !math.IsNaN(v) {
+				continue
+			}
+			if p == numerus.NaNFirst {
+				lo++
+			} else {
+				hi--
+			}
+		}
+
+		expOK := n >= 0 && n < len(in)
+		if p == numerus.NaNSkip && n >= hi {
+			expOK = false
+		}
+
+		d := make([]float64, len(in))
+		copy(d, in)
+		ids := numerus.IntCreateSeq(0, len(in)-1)
+
+		v, i, ok := ops.NthElement(d, ids, n)
+		if ok != expOK {
+			t.Fatalf("Floats64NthElement: n %d: got ok %v", n, ok)
+		}
+		if ok {
+			if !fuzzEqual(exp[n], v) || !fuzzEqual(d[n], v) ||
+				!fuzzEqual(in[i], v) {
+				t.Fatalf("Floats64NthElement: n %d: got %v"+
+					" at %d, want %v", n, v, i, exp[n])
+			}
+			for x := range d {
+				if !fuzzEqual(in[ids[x]], d[x]) {
+					t.Fatalf("Floats64NthElement: index"+
+						" %v does not map %v to"+
+						" input %v", ids, d, in)
+				}
+				if x < lo || x >= hi {
+					if !math.IsNaN(d[x]) {
+						t.Fatalf("Floats64NthElement:"+
+							" NaN is not moved"+
+							" %v", d)
+					}
+					continue
+				}
+				if n < lo || n >= hi {
+					continue
+				}
+				if (x < n && d[x] > v) || (x > n && d[x] < v) {
+					t.Fatalf("Floats64NthElement: n %d:"+
+						" not partitioned %v", n, d)
+				}
+			}
+		}
+
+		v, i, ok = ops.Select(in, n)
+		if ok != expOK || (ok && (!fuzzEqual(exp[n], v) ||
+			!fuzzEqual(in[i], v))) {
+			t.Fatalf("Floats64Select: n %d: got %v at %d and %v",
+				n, v, i, ok)
+		}
+
+		v, i, ok = ops.Median(in)
+		switch {
+		case p == numerus.NaNPropagate && hi-lo < len(in):
+			firstNaN := 0
+			for !math.IsNaN(in[firstNaN]) {
+				firstNaN++
+			}
+			if !ok || !math.IsNaN(v) || i != firstNaN {
+				t.Fatalf("Floats64Median: got %v at %d and %v",
+					v, i, ok)
+			}
+		case hi == lo:
+			if ok {
+				t.Fatalf("Floats64Median: got %v at %d and %v",
+					v, i, ok)
+			}
+		default:
+			m := lo + (hi-lo-1)/2
+			if !ok || v != exp[m] || in[i] != v {
+				t.Fatalf("Floats64Median: got %v at %d and %v,"+
+					" want %v", v, i, ok, exp[m])
+			}
+		}
+	})
+}
+
+//
+// fuzzSearch contains the search functions for sorted slice of type T.
+//
+type fuzzSearch[T numerus.Integer] struct {
+	name          string
+	lowerBound    func(d []T, v T, asc bool) int
+	upperBound    func(d []T, v T, asc bool) int
+	equalRange    func(d []T, v T, asc bool) (int, int)
+	countSorted   func(d []T, v T, asc bool) int
+	isExistSorted func(d []T, v T, asc bool) bool
+	nearest       func(d []T, v T, asc bool) (int, bool)
+}
+
+//
+// check sort the data and check the result of each search function against
+// the linear search.
+//
+func (s fuzzSearch[T]) check(t *testing.T, data []byte, v8 int8, asc bool) {
+	t.Helper()
+
+	d := fuzzExpSorted(fuzzNumbers[T](data), asc)
+	v := T(v8)
+
+	var lo, hi, minDist int
+	minDist = -1
+	for _, dv := range d {
+		if (asc && dv < v) || (!asc && dv > v) {
+			lo++
+		}
+		if dv == v || (asc && dv < v) || (!asc && dv > v) {
+			hi++
+		}
+		dist := int(dv) - int(v)
+		if dist < 0 {
+			dist = -dist
+		}
+		if minDist < 0 || dist < minDist {
+			minDist = dist
+		}
+	}
+
+	assert(t, lo, s.lowerBound(d, v, asc), true)
+	assert(t, hi, s.upperBound(d, v, asc), true)
+
+	gotLo, gotHi := s.equalRange(d, v, asc)
+
+	assert(t, lo, gotLo, true)
+	assert(t, hi, gotHi, true)
+	assert(t, hi-lo, s.countSorted(d, v, asc), true)
+	assert(t, hi > lo, s.isExistSorted(d, v, asc), true)
+	assert(t, numerus.IsExist(d, v), s.isExistSorted(d, v, asc), true)
+
+	i, ok := s.nearest(d, v, asc)
+
+	assert(t, len(d) > 0, ok, true)
+	if ok {
+		dist := int(d[i]) - int(v)
+		if dist < 0 {
+			dist = -dist
+		}
+		if dist != minDist {
+			t.Fatalf("%sNearest: got %v at %d, want distance %d",
+				s.name, d[i], i, minDist)
+		}
+	}
+}
+
+func FuzzIntsSearch(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, int8(1), true)
+		f.Add(seed, int8(-1), false)
+	}
+
+	searchInts := fuzzSearch[int]{
+		"Ints",
+		numerus.IntsLowerBound,
+		numerus.IntsUpperBound,
+		numerus.IntsEqualRange,
+		numerus.IntsCountSorted,
+		numerus.IntsIsExistSorted,
+		numerus.IntsNearest,
+	}
+	searchInts64 := fuzzSearch[int64]{
+		"Ints64",
+		numerus.Ints64LowerBound,
+		numerus.Ints64UpperBound,
+		numerus.Ints64EqualRange,
+		numerus.Ints64CountSorted,
+		numerus.Ints64IsExistSorted,
+		numerus.Ints64Nearest,
+	}
+	searchInt16 := fuzzSearch[int16]{
+		"[int16]",
+		numerus.LowerBound[int16],
+		numerus.UpperBound[int16],
+		numerus.EqualRange[int16],
+		numerus.CountSorted[int16],
+		numerus.IsExistSorted[int16],
+		numerus.Nearest[int16],
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, v8 int8, asc bool) {
+		searchInts.check(t, data, v8, asc)
+		searchInts64.check(t, data, v8, asc)
+		searchInt16.check(t, data, v8, asc)
+	})
+}
+
+func FuzzFloats64Search(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, byte(1), true, policy)
+			f.Add(seed, byte(0xfc), false, policy)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, vb byte, asc bool,
+		policy uint8,
+	) {
+		in := fuzzFloats64(data)
+		v := fuzzFloat(vb)
+		p := numerus.NaNPolicy(policy % 4)
+
+		ops := numerus.Floats64With(p)
+
+		d := fuzzExpFloats64(in, asc, p)
+
+		var lo, hi int
+		minDist := math.Inf(1)
+		for _, dv := range d {
+			c := fuzzCompare(dv, v, asc, p)
+			if c < 0 {
+				lo++
+			}
+			if c <= 0 {
+				hi++
+			}
+			if math.IsNaN(dv) || math.IsNaN(v) {
+				continue
+			}
+			dist := math.Abs(dv - v)
+			if dv == v {
+				dist = 0
+			}
+			if dist < minDist {
+				minDist = dist
+			}
+		}
+
+		assert(t, lo, ops.LowerBound(d, v, asc), true)
+		assert(t, hi, ops.UpperBound(d, v, asc), true)
+
+		count := hi - lo
+		if math.IsNaN(v) && p == numerus.NaNSkip {
+			count = 0
+		}
+
+		assert(t, count, ops.CountSorted(d, v, asc), true)
+		assert(t, ops.IsExist(d, v), ops.IsExistSorted(d, v, asc), true)
+
+		i, ok := ops.Nearest(d, v, asc, -1)
+		if !ok {
+			assert(t, true, math.IsInf(minDist, 1), true)
+			return
+		}
+		dist := math.Abs(d[i] - v)
+		if d[i] == v {
+			dist = 0
+		}
+		assert(t, minDist, dist, true)
+	})
+}
+
+//
+// fuzzCheckMinMax check the result of FindMax and FindMin on data `d` that
+// does not contains NaN.
+//
+func fuzzCheckMinMax[T numerus.Number](t *testing.T, name string, d []T,
+	findMax, findMin func(d []T) (T, int, bool),
+) {
+	t.Helper()
+
+	maxv, maxi, ok := findMax(d)
+	minv, mini, _ := findMin(d)
+
+	if ok != (len(d) > 0) {
+		t.Fatalf("%sFindMax: got ok %v", name, ok)
+	}
+	if !ok {
+		return
+	}
+
+	exp := fuzzExpSorted(d, true)
+
+	assert(t, exp[len(exp)-1], maxv, true)
+	assert(t, exp[0], minv, true)
+	assert(t, maxi, firstIndex(d, maxv), true)
+	assert(t, mini, firstIndex(d, minv), true)
+}
+
+func FuzzFindMinMax(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for policy := uint8(0); policy < 4; policy++ {
+			f.Add(seed, policy)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, policy uint8) {
+		fuzzCheckMinMax(t, "Ints", fuzzNumbers[int](data),
+			numerus.IntsFindMax, numerus.IntsFindMin)
+		fuzzCheckMinMax(t, "Ints64", fuzzNumbers[int64](data),
+			numerus.Ints64FindMax, numerus.Ints64FindMin)
+		fuzzCheckMinMax(t, "[uint16]", fuzzNumbers[uint16](data),
+			numerus.FindMax[uint16], numerus.FindMin[uint16])
+		fuzzCheckMinMax(t, "[float32]", fuzzNumbers[float32](data),
+			numerus.FindMax[float32], numerus.FindMin[float32])
+
+		floats := fuzzFloats64(data)
+		p := numerus.NaNPolicy(policy % 4)
+
+		ops := numerus.Floats64With(p)
+
+		fmaxv, fmaxi, fok := ops.FindMax(floats)
+		fminv, fmini, _ := ops.FindMin(floats)
+
+		exp := fuzzExpFloats64(floats, true, numerus.NaNLast)
+		firstNaN := -1
+		for x, v := range floats {
+			if math.IsNaN(v) {
+				firstNaN = x
+				break
+			}
+		}
+
+		switch {
+		case firstNaN >= 0 && p == numerus.NaNPropagate:
+			assert(t, true, math.IsNaN(fmaxv), true)
+			assert(t, firstNaN, fmaxi, true)
+			assert(t, firstNaN, fmini, true)
+			assert(t, true, fok, true)
+		case len(exp) == 0 || math.IsNaN(exp[0]):
+			assert(t, -1, fmaxi, true)
+			assert(t, -1, fmini, true)
+			assert(t, false, fok, true)
+		default:
+			n := len(exp)
+			for math.IsNaN(exp[n-1]) {
+				n--
+			}
+			assert(t, exp[n-1], fmaxv, true)
+			assert(t, exp[0], fminv, true)
+			assert(t, fmaxv, floats[fmaxi], true)
+			assert(t, fminv, floats[fmini], true)
+			assert(t, true, fok, true)
+		}
+	})
+}
+
+//
+// firstIndex return the index of first `v` in `d`.
+//
+func firstIndex[T numerus.Number](d []T, v T) int {
+	for x, dv := range d {
+		if dv == v {
+			return x
+		}
+	}
+	return -1
+}
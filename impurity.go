@@ -0,0 +1,257 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"math"
+)
+
+//
+// Impurity define the measure of impurity of class distribution.
+//
+type Impurity int
+
+// List of impurity measure.
+const (
+	// ImpurityEntropy measure the impurity using entropy in base 2.
+	ImpurityEntropy Impurity = iota
+
+	// ImpurityGini measure the impurity using Gini index.
+	ImpurityGini
+
+	// ImpurityMisclassification measure the impurity using
+	// misclassification error.
+	ImpurityMisclassification
+)
+
+//
+// Entropy return the entropy of class distribution from their `counts`,
+// which can be the result of Counts or weighted total of CountsWeighted,
+//
+//	H = -sum(p(i) * log(p(i)))
+//
+// where p(i) is counts[i] divided by sum of counts, and the logarithm is in
+// `base`.
+// Use base 2 to get the entropy in bits, or math.E to get it in nats.
+// If `base` is not a finite number greater than 1, base 2 is used, since the
+// logarithm in base 1 is undefined and base less than 1 make the entropy
+// negative.
+//
+// The class with zero count is ignored.
+// If the sum of counts is zero, it will return 0.
+//
+// Example, the entropy of counts [5 5] is 1 bit and [10 0] is 0.
+//
+func Entropy[C Number](counts []C, base float64) (h float64) {
+	total := sumCounts(counts)
+	if total <= 0 {
+		return 0
+	}
+	if !(base > 1) || math.IsInf(base, 1) {
+		base = 2
+	}
+
+	for _, c := range counts {
+		if c <= 0 {
+			continue
+		}
+		p := float64(c) / total
+		h -= p * math.Log(p)
+	}
+	return h / math.Log(base)
+}
+
+//
+// Gini return the Gini index of class distribution from their `counts`,
+//
+//	G = 1 - sum(p(i)^2)
+//
+// If the sum of counts is zero, it will return 0.
+//
+// Example, the Gini index of counts [5 5] is 0.5 and [10 0] is 0.
+//
+func Gini[C Number](counts []C) (g float64) {
+	total := sumCounts(counts)
+	if total <= 0 {
+		return 0
+	}
+
+	g = 1
+	for _, c := range counts {
+		p := float64(c) / total
+		g -= p * p
+	}
+	return g
+}
+
+//
+// MisclassificationError return the misclassification error of class
+// distribution from their `counts`, which is the fraction of data that is
+// not in the majority class,
+//
+//	E = 1 - max(p(i))
+//
+// If the sum of counts is zero, it will return 0.
+//
+func MisclassificationError[C Number](counts []C) float64 {
+	total := sumCounts(counts)
+	if total <= 0 {
+		return 0
+	}
+
+	maxv, _, _ := FindMax(counts)
+
+	return 1 - float64(maxv)/total
+}
+
+//
+// InformationGain return the reduction of entropy in `base` after the data
+// with class distribution `parent` is split into `children`,
+//
+//	IG = H(parent) - sum(n(i)/n * H(children[i]))
+//
+// where n(i) is the sum of counts in children[i] and n is the sum of counts
+// in parent.
+//
+// See Entropy for more information about `base`.
+//
+func InformationGain[C Number](parent []C, children [][]C, base float64) (
+	gain float64,
+) {
+	total := sumCounts(parent)
+	if total <= 0 {
+		return 0
+	}
+
+	gain = Entropy(parent, base)
+	for _, child := range children {
+		gain -= sumCounts(child) / total * Entropy(child, base)
+	}
+	return gain
+}
+
+//
+// GainRatio return the information gain divided by split information, which
+// is the entropy of the size of each children.
+// It reduce the bias of information gain toward split with many children.
+//
+// If the split information is zero, it will return 0.
+//
+// See InformationGain for more information.
+//
+func GainRatio[C Number](parent []C, children [][]C, base float64) float64 {
+	sizes := make([]float64, len(children))
+	for x, child := range children {
+		sizes[x] = sumCounts(child)
+	}
+
+	splitInfo := Entropy(sizes, base)
+	if splitInfo == 0 {
+		return 0
+	}
+	return InformationGain(parent, children, base) / splitInfo
+}
+
+//
+// LabelsEntropy return the entropy of class distribution in `labels`.
+// NaN labels are counted as one class.
+// See Entropy for more information.
+//
+func LabelsEntropy[T Number](labels []T, base float64) float64 {
+	return Entropy(labelCounts(labels), base)
+}
+
+//
+// LabelsGini return the Gini index of class distribution in `labels`.
+// NaN labels are counted as one class.
+// See Gini for more information.
+//
+func LabelsGini[T Number](labels []T) float64 {
+	return Gini(labelCounts(labels))
+}
+
+//
+// LabelsMisclassificationError return the misclassification error of class
+// distribution in `labels`.
+// NaN labels are counted as one class.
+// See MisclassificationError for more information.
+//
+func LabelsMisclassificationError[T Number](labels []T) float64 {
+	return MisclassificationError(labelCounts(labels))
+}
+
+//
+// LabelsInformationGain return the information gain after the `parent`
+// labels is split into `children` labels.
+// See InformationGain for more information.
+//
+func LabelsInformationGain[T Number](parent []T, children [][]T,
+	base float64,
+) float64 {
+	return InformationGain(labelCounts(parent),
+		labelsChildrenCounts(children), base)
+}
+
+//
+// LabelsGainRatio return the gain ratio after the `parent` labels is split
+// into `children` labels.
+// See GainRatio for more information.
+//
+func LabelsGainRatio[T Number](parent []T, children [][]T,
+	base float64,
+) float64 {
+	return GainRatio(labelCounts(parent), labelsChildrenCounts(children),
+		base)
+}
+
+//
+// impurityOf return the impurity of class distribution `counts` using
+// measure `imp`.
+//
+func impurityOf[C Number](imp Impurity, counts []C) float64 {
+	switch imp {
+	case ImpurityGini:
+		return Gini(counts)
+	case ImpurityMisclassification:
+		return MisclassificationError(counts)
+	}
+	return Entropy(counts, 2)
+}
+
+//
+// labelCounts return the count of each distinct value in `labels`.
+//
+func labelCounts[T Number](labels []T) (counts []int) {
+	ft := newFreqTable(labels)
+
+	counts = make([]int, len(ft.freqs))
+	for x, f := range ft.freqs {
+		counts[x] = f.Count
+	}
+	return counts
+}
+
+//
+// labelsChildrenCounts return the label counts of each children.
+// The entropy does not depend on the order of classes, so the counts of
+// each children does not need to have the same classes.
+//
+func labelsChildrenCounts[T Number](children [][]T) (counts [][]int) {
+	counts = make([][]int, len(children))
+	for x, child := range children {
+		counts[x] = labelCounts(child)
+	}
+	return counts
+}
+
+//
+// sumCounts return the sum of `counts` as float.
+//
+func sumCounts[C Number](counts []C) (total float64) {
+	for _, c := range counts {
+		total += float64(c)
+	}
+	return total
+}
@@ -0,0 +1,97 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math"
+	"testing"
+)
+
+func TestEntropy(t *testing.T) {
+	assert(t, float64(1), numerus.Entropy([]int{5, 5}, 2), true)
+	assert(t, float64(0), numerus.Entropy([]int{10, 0}, 2), true)
+	assert(t, float64(0), numerus.Entropy([]int{}, 2), true)
+	assert(t, float64(2), numerus.Entropy([]int{1, 1, 1, 1}, 0), true)
+	assert(t, "0.6931", fmt.Sprintf("%.4f",
+		numerus.Entropy([]float64{0.5, 0.5}, math.E)), true)
+	assert(t, "0.9403", fmt.Sprintf("%.4f",
+		numerus.Entropy([]int{9, 5}, 2)), true)
+
+	// Invalid base fallback to base 2.
+	for _, base := range []float64{-1, 0, 0.5, 1, nan, math.Inf(1)} {
+		assert(t, float64(1), numerus.Entropy([]int{5, 5}, base),
+			true)
+	}
+}
+
+func TestGini(t *testing.T) {
+	assert(t, 0.5, numerus.Gini([]int{5, 5}), true)
+	assert(t, float64(0), numerus.Gini([]int{10, 0}), true)
+	assert(t, float64(0), numerus.Gini([]float64{}), true)
+	assert(t, "0.32", fmt.Sprintf("%.2f", numerus.Gini([]int64{8, 2})),
+		true)
+}
+
+func TestMisclassificationError(t *testing.T) {
+	assert(t, 0.5, numerus.MisclassificationError([]int{5, 5}), true)
+	assert(t, 0.25, numerus.MisclassificationError([]int{1, 6, 1}), true)
+	assert(t, float64(0), numerus.MisclassificationError([]int{}), true)
+}
+
+func TestInformationGain(t *testing.T) {
+	// The "outlook" attribute from play tennis data set.
+	parent := []int{9, 5}
+	children := [][]int{{2, 3}, {4, 0}, {3, 2}}
+
+	got := numerus.InformationGain(parent, children, 2)
+
+	assert(t, "0.2467", fmt.Sprintf("%.4f", got), true)
+
+	got = numerus.GainRatio(parent, children, 2)
+
+	assert(t, "0.1564", fmt.Sprintf("%.4f", got), true)
+
+	assert(t, float64(0), numerus.GainRatio(parent, [][]int{parent}, 2),
+		true)
+
+	for _, base := range []float64{0.5, 1} {
+		got = numerus.InformationGain(parent, children, base)
+
+		assert(t, "0.2467", fmt.Sprintf("%.4f", got), true)
+
+		got = numerus.GainRatio(parent, children, base)
+
+		assert(t, "0.1564", fmt.Sprintf("%.4f", got), true)
+	}
+}
+
+func TestLabelsImpurity(t *testing.T) {
+	labels := []int{1, 2, 1, 2, 3, 3, 3, 3}
+
+	assert(t, 1.5, numerus.LabelsEntropy(labels, 2), true)
+	assert(t, 0.625, numerus.LabelsGini(labels), true)
+	assert(t, 0.5, numerus.LabelsMisclassificationError(labels), true)
+
+	children := [][]int{{1, 2, 1, 2}, {3, 3, 3, 3}}
+
+	assert(t, float64(1), numerus.LabelsInformationGain(labels, children,
+		2), true)
+	assert(t, float64(1), numerus.LabelsGainRatio(labels, children, 2),
+		true)
+
+	for _, base := range []float64{0.5, 1} {
+		assert(t, 1.5, numerus.LabelsEntropy(labels, base), true)
+		assert(t, float64(1), numerus.LabelsInformationGain(labels,
+			children, base), true)
+		assert(t, float64(1), numerus.LabelsGainRatio(labels,
+			children, base), true)
+	}
+
+	// NaN labels are counted as one class.
+	assert(t, float64(1), numerus.LabelsEntropy(
+		[]float64{nan, 1, nan, 1}, 2), true)
+}
@@ -14,23 +14,7 @@ package numerus
 // as index of maximum value.
 //
 func IntsFindMax(d []int) (maxv int, maxi int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
-
-	x := 0
-	maxv = d[x]
-	maxi = x
-
-	for x = 1; x < l; x++ {
-		if d[x] > maxv {
-			maxv = d[x]
-			maxi = x
-		}
-	}
-
-	return maxv, maxi, true
+	return FindMax(d)
 }
 
 //
@@ -43,49 +27,21 @@ func IntsFindMax(d []int) (maxv int, maxi int, ok bool) {
 // as minimum index.
 //
 func IntsFindMin(d []int) (minv int, mini int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
-
-	x := 0
-	minv = d[x]
-	mini = x
-
-	for x = 1; x < l; x++ {
-		if d[x] < minv {
-			minv = d[x]
-			mini = x
-		}
-	}
-
-	return minv, mini, true
+	return FindMin(d)
 }
 
 //
 // IntsSum return sum of all value in slice.
 //
 func IntsSum(d []int) (sum int) {
-	for _, v := range d {
-		sum += v
-	}
-	return
+	return Sum(d)
 }
 
 //
 // IntsCount will count number of class in data.
 //
 func IntsCount(d []int, class int) (count int) {
-	if len(d) <= 0 {
-		return
-	}
-
-	for _, v := range d {
-		if v == class {
-			count++
-		}
-	}
-	return count
+	return Count(d, class)
 }
 
 //
@@ -99,16 +55,7 @@ func IntsCount(d []int, class int) (count int) {
 //	1 : 2   -> 1
 //
 func IntsCounts(d, classes []int) (counts []int) {
-	if len(classes) <= 0 {
-		return
-	}
-
-	counts = make([]int, len(classes))
-
-	for x, c := range classes {
-		counts[x] = IntsCount(d, c)
-	}
-	return
+	return Counts(d, classes)
 }
 
 //
@@ -126,37 +73,23 @@ func IntsCounts(d, classes []int) (counts []int) {
 // `true`.
 //
 func IntsMaxCountOf(d, classes []int) (int, bool) {
-	if len(classes) == 0 {
-		return -1, false
-	}
-	if len(d) == 0 {
-		return -2, false
-	}
-
-	counts := IntsCounts(d, classes)
-
-	_, maxi, _ := IntsFindMax(counts)
-	if maxi < 0 {
-		return -1, false
-	}
-
-	return classes[maxi], true
+	return MaxCountOf(d, classes)
 }
 
 //
 // IntsSwap swap two indices value of integer.
 //
 func IntsSwap(d []int, x, y int) {
-	if x == y {
-		return
-	}
-	if len(d) <= 1 || x > len(d) || y > len(d) {
-		return
-	}
+	Swap(d, x, y)
+}
 
-	tmp := d[x]
-	d[x] = d[y]
-	d[y] = tmp
+//
+// IntsSafeSwap swap two indices value of integer, or return an error if one
+// of the index is out of range.
+// See SafeSwap for more information.
+//
+func IntsSafeSwap(d []int, x, y int) error {
+	return SafeSwap(d, x, y)
 }
 
 //
@@ -164,12 +97,7 @@ func IntsSwap(d []int, x, y int) {
 // otherwise it will return false.
 //
 func IntsIsExist(d []int, i int) bool {
-	for _, v := range d {
-		if i == v {
-			return true
-		}
-	}
-	return false
+	return IsExist(d, i)
 }
 
 //
@@ -193,216 +121,288 @@ func IntsTo64(ints []int) []int64 {
 // - `r` is end index of slice to be sorted.
 //
 func IntsInsertionSort(d, ids []int, l, r int, asc bool) {
-	for x := l; x < r; x++ {
-		for y := x + 1; y < r; y++ {
-			if asc {
-				if d[x] > d[y] {
-					IntsSwap(ids, x, y)
-					IntsSwap(d, x, y)
-				}
-			} else {
-				if d[x] < d[y] {
-					IntsSwap(ids, x, y)
-					IntsSwap(d, x, y)
-				}
-			}
-		}
-	}
+	InsertionSort(d, ids, l, r, asc)
 }
 
 //
 // IntsSortByIndex will sort the slice `d` using sorted index `sortedIds`.
 //
 func IntsSortByIndex(d *[]int, sortedIds []int) {
-	newd := make([]int, len(*d))
-
-	for i := range sortedIds {
-		newd[i] = (*d)[sortedIds[i]]
-	}
+	SortByIndex(d, sortedIds)
+}
 
-	(*d) = newd
+//
+// IntsSafeSortByIndex will sort the slice `d` using sorted index
+// `sortedIds`, or return an error if the index is not valid.
+// See SafeSortByIndex for more information.
+//
+func IntsSafeSortByIndex(d *[]int, sortedIds []int) error {
+	return SafeSortByIndex(d, sortedIds)
 }
 
 //
 // IntsInplaceMergesort in-place merge-sort without memory allocation.
 //
 func IntsInplaceMergesort(d []int, idx []int, l, r int, asc bool) {
-	// (0) If data length == Threshold, then
-	if l+SortThreshold >= r {
-		// (0.1) use insertion sort.
-		IntsInsertionSort(d, idx, l, r, asc)
-		return
-	}
+	InplaceMergesort(d, idx, l, r, asc)
+}
 
-	// (1) Divide into left and right.
-	res := (r + l) % 2
-	c := (r + l) / 2
-	if res == 1 {
-		c++
-	}
+//
+// IntsInplaceMergesortParallel sort the slice of integer using in-place
+// merge-sort, with the left and right halves sorted concurrently by at most
+// `workers` goroutines.
+// See InplaceMergesortParallel for more information.
+//
+func IntsInplaceMergesortParallel(d []int, idx []int, l, r int, asc bool,
+	workers int,
+) {
+	InplaceMergesortParallel(d, idx, l, r, asc, workers)
+}
 
-	// (2) Sort left.
-	IntsInplaceMergesort(d, idx, l, c, asc)
-
-	// (3) Sort right.
-	IntsInplaceMergesort(d, idx, c, r, asc)
-
-	// (4) Merge sorted left and right.
-	if asc {
-		if d[c-1] <= d[c] {
-			// (4.1) If the last element of the left is lower then
-			// the first element of the right, i.e. [1 2] [3 4];
-			// no merging needed, return immediately.
-			return
-		}
-	} else {
-		if d[c-1] >= d[c] {
-			return
-		}
-	}
+//
+// IntsIndirectSort will sort the data and return the sorted index.
+//
+func IntsIndirectSort(d []int, asc bool) (sortedIdx []int) {
+	return IndirectSort(d, asc)
+}
+
+//
+// IntsSort sort the slice of integer using the algorithm in `opts`.
+// See Sort for more information.
+//
+func IntsSort(d []int, idx []int, l, r int, asc bool, opts *SortOptions) {
+	Sort(d, idx, l, r, asc, opts)
+}
+
+//
+// IntsRadixSort sort the slice of integer using LSD radix sort and permute
+// `idx` in step with `d`.
+// See RadixSort for more information.
+//
+func IntsRadixSort(d []int, idx []int, asc bool) {
+	RadixSort(d, idx, asc)
+}
+
+//
+// IntsIndirectRadixSort will sort the data using radix sort and return the
+// sorted index.
+// It is an alternative of IntsIndirectSort that run in linear time.
+//
+func IntsIndirectRadixSort(d []int, asc bool) (sortedIdx []int) {
+	return IndirectRadixSort(d, asc)
+}
+
+//
+// IntsTopK return the `k` largest values in `d` and their index, sorted in
+// descending order.
+// See TopK for more information.
+//
+func IntsTopK(d []int, k int) (vals []int, ids []int) {
+	return TopK(d, k)
+}
+
+//
+// IntsBottomK return the `k` smallest values in `d` and their index, sorted
+// in ascending order.
+// See BottomK for more information.
+//
+func IntsBottomK(d []int, k int) (vals []int, ids []int) {
+	return BottomK(d, k)
+}
 
-	intsInplaceMerge(d, idx, l, c, r, asc)
+//
+// IntsNthElement partially sort the data in place, so the element at position
+// `n` is the element that would be there if `d` is sorted in ascending order.
+// See NthElement for more information.
+//
+func IntsNthElement(d []int, idx []int, n int) (v int, i int, ok bool) {
+	return NthElement(d, idx, n)
 }
 
 //
-// Let `x` be the first index of left-side, `y` be the first index of
-// the right-side, and `r` as length of slice `d`
+// IntsSelect return the `n`-th smallest element in `d` and its index,
+// without modifying `d`.
 //
-func intsInplaceMerge(d []int, idx []int, x, y, r int, asc bool) {
-	var ylast int
+func IntsSelect(d []int, n int) (v int, i int, ok bool) {
+	return Select(d, n)
+}
 
-	// (4.3) Loop until either x or y reached the maximum slice.
-	for x < r && y < r {
-		// (4.3.1) IF DATA[x] <= DATA[y]
-		if asc {
-			if d[x] <= d[y] {
-				x++
+//
+// IntsMedian return the lower median of data and its index, without
+// modifying `d`.
+//
+func IntsMedian(d []int) (v int, i int, ok bool) {
+	return Median(d)
+}
 
-				// (4.3.1.2) IF x > y THEN GOTO 4.3
-				if x >= y {
-					goto next
-				}
+//
+// IntsArgsort return the index that would sort the data, without modifying
+// `d`.
+// See Argsort for more information.
+//
+func IntsArgsort(d []int, asc bool) (sortedIdx []int) {
+	return Argsort(d, asc)
+}
 
-				// (4.3.1.3) GOTO 4.3
-				continue
-			}
-		} else {
-			if d[x] >= d[y] {
-				x++
+//
+// IntsMerge merge the already sorted slices of integer into one sorted slice.
+// See Merge for more information.
+//
+func IntsMerge(asc bool, ds ...[]int) (out []int, src, ids []int) {
+	return Merge(asc, ds...)
+}
 
-				if x >= y {
-					goto next
-				}
+//
+// IntsLowerBound return the index of first element in sorted slice that is
+// not placed before `v`.
+// See LowerBound for more information.
+//
+func IntsLowerBound(d []int, v int, asc bool) int {
+	return LowerBound(d, v, asc)
+}
 
-				continue
-			}
-		}
+//
+// IntsUpperBound return the index of first element in sorted slice that is
+// placed after `v`.
+// See UpperBound for more information.
+//
+func IntsUpperBound(d []int, v int, asc bool) int {
+	return UpperBound(d, v, asc)
+}
 
-		// (4.3.2) LET YLAST := the next DATA[y] that is less DATA[x]
-		ylast = intsMoveY(d, x, y, r, asc)
+//
+// IntsEqualRange return the range of index that contains elements equal to
+// `v` in sorted slice.
+//
+func IntsEqualRange(d []int, v int, asc bool) (lo, hi int) {
+	return EqualRange(d, v, asc)
+}
 
-		// (4.3.3) SWAP DATA, X, Y, YLAST
-		intsMultiswap(d, idx, x, y, ylast)
+//
+// IntsIsExistSorted will return true if value `v` exist in sorted slice
+// `d`, otherwise it will return false.
+//
+func IntsIsExistSorted(d []int, v int, asc bool) bool {
+	return IsExistSorted(d, v, asc)
+}
 
-	next:
-		// (4.3.4) LET Y := the minimum value between x and r on `d`
-		intsMinY(d, &x, &y, r, asc)
-	}
+//
+// IntsCountSorted will count number of `v` in sorted slice `d`.
+//
+func IntsCountSorted(d []int, v int, asc bool) int {
+	return CountSorted(d, v, asc)
 }
 
-// (4.3.4) LET Y := the minimum value between x and r on `d`
-func intsMinY(d []int, x, y *int, r int, asc bool) {
-	for *x < r {
-		if asc {
-			*y = intsMin(d, *x, r)
-		} else {
-			*y = intsMax(d, *x, r)
-		}
+//
+// IntsNearest return the index of element in sorted slice `d` that has the
+// smallest distance to `v`.
+// See Nearest for more information.
+//
+func IntsNearest(d []int, v int, asc bool) (i int, ok bool) {
+	return Nearest(d, v, asc)
+}
 
-		if *y != *x {
-			break
-		}
-		(*x)++
-	}
+//
+// IntsIsSorted will return true if data is sorted in ascending order if
+// `asc` is true, or in descending order otherwise.
+// See IsSorted for more information.
+//
+func IntsIsSorted(d []int, asc, strict bool) bool {
+	return IsSorted(d, asc, strict)
 }
 
-func intsMoveY(d []int, x, y, r int, asc bool) int {
-	yorg := y
-	y++
-	for y < r {
-		if asc {
-			if d[y] >= d[x] {
-				break
-			}
-			if d[y] < d[yorg] {
-				break
-			}
-		} else {
-			if d[y] <= d[x] {
-				break
-			}
-			if d[y] > d[yorg] {
-				break
-			}
-		}
-		y++
-	}
-	return y
-}
-
-func intsMultiswap(d []int, idx []int, x, y, ylast int) int {
-	for y < ylast {
-		IntsSwap(idx, x, y)
-		IntsSwap(d, x, y)
-		x++
-		y++
-		if y >= ylast {
-			return y
-		}
-		if d[x] <= d[y] {
-			return y
-		}
-	}
+//
+// IntsRuns return the natural ascending and strictly descending runs in
+// data.
+// See Runs for more information.
+//
+func IntsRuns(d []int) (runs []Run) {
+	return Runs(d)
+}
 
-	return y
+//
+// IntsRank return the rank of each element in `d`.
+// See Rank for more information.
+//
+func IntsRank(d []int, asc bool, method RankMethod) (ranks []int) {
+	return Rank(d, asc, method)
 }
 
-func intsMin(d []int, l, r int) (m int) {
-	min := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] <= min {
-			min = d[l]
-			m = l
-		}
-	}
-	return
+//
+// IntsRankAverage return the rank of each element in `d`, where equal
+// elements are assigned the average of their ranks.
+//
+func IntsRankAverage(d []int, asc bool) (ranks []float64) {
+	return RankAverage(d, asc)
 }
 
-func intsMax(d []int, l, r int) (m int) {
-	maxv := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] >= maxv {
-			maxv = d[l]
-			m = l
-		}
-	}
-	return m
+//
+// IntsFrequencies return each distinct value in `d` with their count and
+// index of their first occurrence.
+// See Frequencies for more information.
+//
+func IntsFrequencies(d []int, order FrequencyOrder) (
+	freqs []Frequency[int],
+) {
+	return Frequencies(d, order)
 }
 
 //
-// IntsIndirectSort will sort the data and return the sorted index.
+// IntsMode return the most frequent value in `d` and their count.
+// See Mode for more information.
 //
-func IntsIndirectSort(d []int, asc bool) (sortedIdx []int) {
-	dlen := len(d)
+func IntsMode(d []int, opts *ModeOptions) (modes []int, count int, ok bool) {
+	return Mode(d, opts)
+}
 
-	sortedIdx = make([]int, dlen)
-	for i := 0; i < dlen; i++ {
-		sortedIdx[i] = i
-	}
+//
+// IntsModeWeighted return the value in `d` with the maximum total weight.
+// See ModeWeighted for more information.
+//
+func IntsModeWeighted(d []int, w []float64, opts *ModeOptions) (
+	modes []int, weight float64, err error,
+) {
+	return ModeWeighted(d, w, opts)
+}
+
+//
+// IntsCountWeighted return the total weight of `class` in data.
+// See CountWeighted for more information.
+//
+func IntsCountWeighted(d []int, w []float64, class int) (
+	total float64, err error,
+) {
+	return CountWeighted(d, w, class)
+}
+
+//
+// IntsCountsWeighted return the total weight of each class in data.
+// See CountsWeighted for more information.
+//
+func IntsCountsWeighted(d []int, w []float64, classes []int) (
+	totals []float64, err error,
+) {
+	return CountsWeighted(d, w, classes)
+}
 
-	IntsInplaceMergesort(d, sortedIdx, 0, dlen, asc)
+//
+// IntsMaxCountOfWeighted return the class with maximum total weight in
+// data.
+// See MaxCountOfWeighted for more information.
+//
+func IntsMaxCountOfWeighted(d []int, w []float64, classes []int) (
+	class int, ok bool, err error,
+) {
+	return MaxCountOfWeighted(d, w, classes)
+}
 
-	return
+//
+// IntsCrossTab count the occurrence of each pair of class in `rows` and
+// `cols`.
+// See CrossTab for more information.
+//
+func IntsCrossTab(rows, cols, rowClasses, colClasses []int) (
+	ct *CrossTable[int], err error,
+) {
+	return CrossTab(rows, cols, rowClasses, colClasses)
 }
@@ -14,23 +14,7 @@ package numerus
 // as index of maximum value.
 //
 func Ints64FindMax(d []int64) (maxv int64, maxi int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
-
-	x := 0
-	maxv = d[x]
-	maxi = x
-
-	for x = 1; x < l; x++ {
-		if d[x] > maxv {
-			maxv = d[x]
-			maxi = x
-		}
-	}
-
-	return maxv, maxi, true
+	return FindMax(d)
 }
 
 //
@@ -43,49 +27,21 @@ func Ints64FindMax(d []int64) (maxv int64, maxi int, ok bool) {
 // as minimum index.
 //
 func Ints64FindMin(d []int64) (minv int64, mini int, ok bool) {
-	l := len(d)
-	if l <= 0 {
-		return -1, -1, false
-	}
-
-	x := 0
-	minv = d[x]
-	mini = x
-
-	for x = 1; x < l; x++ {
-		if d[x] < minv {
-			minv = d[x]
-			mini = x
-		}
-	}
-
-	return minv, mini, true
+	return FindMin(d)
 }
 
 //
 // Ints64Sum return sum of all value in slice.
 //
 func Ints64Sum(d []int64) (sum int64) {
-	for _, v := range d {
-		sum += v
-	}
-	return sum
+	return Sum(d)
 }
 
 //
 // Ints64Count will count number of class in data.
 //
 func Ints64Count(d []int64, class int64) (count int) {
-	if len(d) <= 0 {
-		return
-	}
-
-	for _, v := range d {
-		if v == class {
-			count++
-		}
-	}
-	return count
+	return Count(d, class)
 }
 
 //
@@ -99,16 +55,7 @@ func Ints64Count(d []int64, class int64) (count int) {
 //	1 : 2   -> 1
 //
 func Ints64Counts(d, classes []int64) (counts []int) {
-	if len(classes) <= 0 {
-		return
-	}
-
-	counts = make([]int, len(classes))
-
-	for x, c := range classes {
-		counts[x] = Ints64Count(d, c)
-	}
-	return
+	return Counts(d, classes)
 }
 
 //
@@ -124,37 +71,23 @@ func Ints64Counts(d, classes []int64) (counts []int) {
 // will count 0 as 3, 1 as 2; and return 0.
 //
 func Ints64MaxCountOf(d, classes []int64) (int64, bool) {
-	if len(classes) == 0 {
-		return -1, false
-	}
-	if len(d) == 0 {
-		return -2, false
-	}
-
-	counts := Ints64Counts(d, classes)
-
-	_, maxi, _ := IntsFindMax(counts)
-	if maxi < 0 {
-		return -1, false
-	}
-
-	return classes[maxi], true
+	return MaxCountOf(d, classes)
 }
 
 //
 // Ints64Swap swap two indices value of integer.
 //
 func Ints64Swap(d []int64, x, y int) {
-	if x == y {
-		return
-	}
-	if len(d) <= 1 || x > len(d) || y > len(d) {
-		return
-	}
+	Swap(d, x, y)
+}
 
-	tmp := d[x]
-	d[x] = d[y]
-	d[y] = tmp
+//
+// Ints64SafeSwap swap two indices value of integer, or return an error if
+// one of the index is out of range.
+// See SafeSwap for more information.
+//
+func Ints64SafeSwap(d []int64, x, y int) error {
+	return SafeSwap(d, x, y)
 }
 
 //
@@ -162,12 +95,7 @@ func Ints64Swap(d []int64, x, y int) {
 // otherwise it will return false.
 //
 func Ints64IsExist(d []int64, i int64) bool {
-	for _, v := range d {
-		if i == v {
-			return true
-		}
-	}
-	return false
+	return IsExist(d, i)
 }
 
 //
@@ -180,216 +108,293 @@ func Ints64IsExist(d []int64, i int64) bool {
 // - `r` is end index of slice to be sorted.
 //
 func Ints64InsertionSort(d []int64, ids []int, l, r int, asc bool) {
-	for x := l; x < r; x++ {
-		for y := x + 1; y < r; y++ {
-			if asc {
-				if d[x] > d[y] {
-					IntsSwap(ids, x, y)
-					Ints64Swap(d, x, y)
-				}
-			} else {
-				if d[x] < d[y] {
-					IntsSwap(ids, x, y)
-					Ints64Swap(d, x, y)
-				}
-			}
-		}
-	}
+	InsertionSort(d, ids, l, r, asc)
 }
 
 //
 // Ints64SortByIndex will sort the slice `d` using sorted index `sortedIds`.
 //
 func Ints64SortByIndex(d *[]int64, sortedIds []int) {
-	newd := make([]int64, len(*d))
-
-	for i := range sortedIds {
-		newd[i] = (*d)[sortedIds[i]]
-	}
+	SortByIndex(d, sortedIds)
+}
 
-	(*d) = newd
+//
+// Ints64SafeSortByIndex will sort the slice `d` using sorted index
+// `sortedIds`, or return an error if the index is not valid.
+// See SafeSortByIndex for more information.
+//
+func Ints64SafeSortByIndex(d *[]int64, sortedIds []int) error {
+	return SafeSortByIndex(d, sortedIds)
 }
 
 //
 // Ints64InplaceMergesort in-place merge-sort without memory allocation.
 //
 func Ints64InplaceMergesort(d []int64, idx []int, l, r int, asc bool) {
-	// (0) If data length == Threshold, then
-	if l+SortThreshold >= r {
-		// (0.1) use insertion sort.
-		Ints64InsertionSort(d, idx, l, r, asc)
-		return
-	}
-
-	// (1) Divide into left and right.
-	res := (r + l) % 2
-	c := (r + l) / 2
-	if res == 1 {
-		c++
-	}
-
-	// (2) Sort left.
-	Ints64InplaceMergesort(d, idx, l, c, asc)
-
-	// (3) Sort right.
-	Ints64InplaceMergesort(d, idx, c, r, asc)
-
-	// (4) Merge sorted left and right.
-	if asc {
-		if d[c-1] <= d[c] {
-			// (4.1) If the last element of the left is lower then
-			// the first element of the right, i.e. [1 2] [3 4];
-			// no merging needed, return immediately.
-			return
-		}
-	} else {
-		if d[c-1] >= d[c] {
-			return
-		}
-	}
-
-	ints64InplaceMerge(d, idx, l, c, r, asc)
-}
-
-//
-// Let `x` be the first index of left-side, `y` be the first index of
-// the right-side, and `r` as length of slice `d`
-//
-func ints64InplaceMerge(d []int64, idx []int, x, y, r int, asc bool) {
-	var ylast int
-
-	// (4.3) Loop until either x or y reached the maximum slice.
-	for x < r && y < r {
-		// (4.3.1) IF DATA[x] <= DATA[y]
-		if asc {
-			if d[x] <= d[y] {
-				x++
-
-				// (4.3.1.2) IF x > y THEN GOTO 4.3
-				if x >= y {
-					goto next
-				}
-
-				// (4.3.1.3) GOTO 4.3
-				continue
-			}
-		} else {
-			if d[x] >= d[y] {
-				x++
-
-				if x >= y {
-					goto next
-				}
-
-				continue
-			}
-		}
-
-		// (4.3.2) LET YLAST := the next DATA[y] that is less DATA[x]
-		ylast = ints64MoveY(d, x, y, r, asc)
-
-		// (4.3.3) SWAP DATA, X, Y, YLAST
-		ints64Multiswap(d, idx, x, y, ylast)
-
-	next:
-		// (4.3.4) LET Y := the minimum value between x and r on `d`
-		ints64MinY(d, &x, &y, r, asc)
-	}
-}
-
-// (4.3.4) LET Y := the minimum value between x and r on `d`
-func ints64MinY(d []int64, x, y *int, r int, asc bool) {
-	for *x < r {
-		if asc {
-			*y = ints64Min(d, *x, r)
-		} else {
-			*y = ints64Max(d, *x, r)
-		}
-
-		if *y != *x {
-			break
-		}
-		(*x)++
-	}
-}
-
-func ints64MoveY(d []int64, x, y, r int, asc bool) int {
-	yorg := y
-	y++
-	for y < r {
-		if asc {
-			if d[y] >= d[x] {
-				break
-			}
-			if d[y] < d[yorg] {
-				break
-			}
-		} else {
-			if d[y] <= d[x] {
-				break
-			}
-			if d[y] > d[yorg] {
-				break
-			}
-		}
-		y++
-	}
-	return y
-}
-
-func ints64Multiswap(d []int64, idx []int, x, y, ylast int) int {
-	for y < ylast {
-		IntsSwap(idx, x, y)
-		Ints64Swap(d, x, y)
-		x++
-		y++
-		if y >= ylast {
-			return y
-		}
-		if d[x] <= d[y] {
-			return y
-		}
-	}
-
-	return y
-}
-
-func ints64Min(d []int64, l, r int) (m int) {
-	min := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] <= min {
-			min = d[l]
-			m = l
-		}
-	}
-	return
-}
-
-func ints64Max(d []int64, l, r int) (m int) {
-	maxv := d[l]
-	m = l
-	for l++; l < r; l++ {
-		if d[l] >= maxv {
-			maxv = d[l]
-			m = l
-		}
-	}
-	return m
+	InplaceMergesort(d, idx, l, r, asc)
+}
+
+//
+// Ints64InplaceMergesortParallel sort the slice of 64bit integer using in-place
+// merge-sort, with the left and right halves sorted concurrently by at most
+// `workers` goroutines.
+// See InplaceMergesortParallel for more information.
+//
+func Ints64InplaceMergesortParallel(d []int64, idx []int, l, r int, asc bool,
+	workers int,
+) {
+	InplaceMergesortParallel(d, idx, l, r, asc, workers)
 }
 
 //
 // Ints64IndirectSort will sort the data and return the sorted index.
 //
 func Ints64IndirectSort(d []int64, asc bool) (sortedIdx []int) {
-	dlen := len(d)
+	return IndirectSort(d, asc)
+}
+
+//
+// Ints64Sort sort the slice of 64bit integer using the algorithm in `opts`.
+// See Sort for more information.
+//
+func Ints64Sort(d []int64, idx []int, l, r int, asc bool,
+	opts *SortOptions,
+) {
+	Sort(d, idx, l, r, asc, opts)
+}
+
+//
+// Ints64RadixSort sort the slice of 64bit integer using LSD radix sort and
+// permute `idx` in step with `d`.
+// See RadixSort for more information.
+//
+func Ints64RadixSort(d []int64, idx []int, asc bool) {
+	RadixSort(d, idx, asc)
+}
+
+//
+// Ints64IndirectRadixSort will sort the data using radix sort and return the
+// sorted index.
+// It is an alternative of Ints64IndirectSort that run in linear time.
+//
+func Ints64IndirectRadixSort(d []int64, asc bool) (sortedIdx []int) {
+	return IndirectRadixSort(d, asc)
+}
+
+//
+// Ints64TopK return the `k` largest values in `d` and their index, sorted in
+// descending order.
+// See TopK for more information.
+//
+func Ints64TopK(d []int64, k int) (vals []int64, ids []int) {
+	return TopK(d, k)
+}
+
+//
+// Ints64BottomK return the `k` smallest values in `d` and their index, sorted
+// in ascending order.
+// See BottomK for more information.
+//
+func Ints64BottomK(d []int64, k int) (vals []int64, ids []int) {
+	return BottomK(d, k)
+}
+
+//
+// Ints64NthElement partially sort the data in place, so the element at position
+// `n` is the element that would be there if `d` is sorted in ascending order.
+// See NthElement for more information.
+//
+func Ints64NthElement(d []int64, idx []int, n int) (v int64, i int, ok bool) {
+	return NthElement(d, idx, n)
+}
+
+//
+// Ints64Select return the `n`-th smallest element in `d` and its index,
+// without modifying `d`.
+//
+func Ints64Select(d []int64, n int) (v int64, i int, ok bool) {
+	return Select(d, n)
+}
+
+//
+// Ints64Median return the lower median of data and its index, without
+// modifying `d`.
+//
+func Ints64Median(d []int64) (v int64, i int, ok bool) {
+	return Median(d)
+}
+
+//
+// Ints64Argsort return the index that would sort the data, without modifying
+// `d`.
+// See Argsort for more information.
+//
+func Ints64Argsort(d []int64, asc bool) (sortedIdx []int) {
+	return Argsort(d, asc)
+}
+
+//
+// Ints64Merge merge the already sorted slices of 64bit integer into one
+// sorted slice.
+// See Merge for more information.
+//
+func Ints64Merge(asc bool, ds ...[]int64) (out []int64, src, ids []int) {
+	return Merge(asc, ds...)
+}
 
-	sortedIdx = make([]int, dlen)
-	for i := 0; i < dlen; i++ {
-		sortedIdx[i] = i
-	}
+//
+// Ints64LowerBound return the index of first element in sorted slice that is
+// not placed before `v`.
+// See LowerBound for more information.
+//
+func Ints64LowerBound(d []int64, v int64, asc bool) int {
+	return LowerBound(d, v, asc)
+}
+
+//
+// Ints64UpperBound return the index of first element in sorted slice that is
+// placed after `v`.
+// See UpperBound for more information.
+//
+func Ints64UpperBound(d []int64, v int64, asc bool) int {
+	return UpperBound(d, v, asc)
+}
+
+//
+// Ints64EqualRange return the range of index that contains elements equal to
+// `v` in sorted slice.
+//
+func Ints64EqualRange(d []int64, v int64, asc bool) (lo, hi int) {
+	return EqualRange(d, v, asc)
+}
+
+//
+// Ints64IsExistSorted will return true if value `v` exist in sorted slice
+// `d`, otherwise it will return false.
+//
+func Ints64IsExistSorted(d []int64, v int64, asc bool) bool {
+	return IsExistSorted(d, v, asc)
+}
+
+//
+// Ints64CountSorted will count number of `v` in sorted slice `d`.
+//
+func Ints64CountSorted(d []int64, v int64, asc bool) int {
+	return CountSorted(d, v, asc)
+}
+
+//
+// Ints64Nearest return the index of element in sorted slice `d` that has the
+// smallest distance to `v`.
+// See Nearest for more information.
+//
+func Ints64Nearest(d []int64, v int64, asc bool) (i int, ok bool) {
+	return Nearest(d, v, asc)
+}
+
+//
+// Ints64IsSorted will return true if data is sorted in ascending order if
+// `asc` is true, or in descending order otherwise.
+// See IsSorted for more information.
+//
+func Ints64IsSorted(d []int64, asc, strict bool) bool {
+	return IsSorted(d, asc, strict)
+}
+
+//
+// Ints64Runs return the natural ascending and strictly descending runs in
+// data.
+// See Runs for more information.
+//
+func Ints64Runs(d []int64) (runs []Run) {
+	return Runs(d)
+}
+
+//
+// Ints64Rank return the rank of each element in `d`.
+// See Rank for more information.
+//
+func Ints64Rank(d []int64, asc bool, method RankMethod) (ranks []int) {
+	return Rank(d, asc, method)
+}
+
+//
+// Ints64RankAverage return the rank of each element in `d`, where equal
+// elements are assigned the average of their ranks.
+//
+func Ints64RankAverage(d []int64, asc bool) (ranks []float64) {
+	return RankAverage(d, asc)
+}
+
+//
+// Ints64Frequencies return each distinct value in `d` with their count and
+// index of their first occurrence.
+// See Frequencies for more information.
+//
+func Ints64Frequencies(d []int64, order FrequencyOrder) (
+	freqs []Frequency[int64],
+) {
+	return Frequencies(d, order)
+}
+
+//
+// Ints64Mode return the most frequent value in `d` and their count.
+// See Mode for more information.
+//
+func Ints64Mode(d []int64, opts *ModeOptions) (
+	modes []int64, count int, ok bool,
+) {
+	return Mode(d, opts)
+}
+
+//
+// Ints64ModeWeighted return the value in `d` with the maximum total weight.
+// See ModeWeighted for more information.
+//
+func Ints64ModeWeighted(d []int64, w []float64, opts *ModeOptions) (
+	modes []int64, weight float64, err error,
+) {
+	return ModeWeighted(d, w, opts)
+}
+
+//
+// Ints64CountWeighted return the total weight of `class` in data.
+// See CountWeighted for more information.
+//
+func Ints64CountWeighted(d []int64, w []float64, class int64) (
+	total float64, err error,
+) {
+	return CountWeighted(d, w, class)
+}
+
+//
+// Ints64CountsWeighted return the total weight of each class in data.
+// See CountsWeighted for more information.
+//
+func Ints64CountsWeighted(d []int64, w []float64, classes []int64) (
+	totals []float64, err error,
+) {
+	return CountsWeighted(d, w, classes)
+}
 
-	Ints64InplaceMergesort(d, sortedIdx, 0, dlen, asc)
+//
+// Ints64MaxCountOfWeighted return the class with maximum total weight in
+// data.
+// See MaxCountOfWeighted for more information.
+//
+func Ints64MaxCountOfWeighted(d []int64, w []float64, classes []int64) (
+	class int64, ok bool, err error,
+) {
+	return MaxCountOfWeighted(d, w, classes)
+}
 
-	return
+//
+// Ints64CrossTab count the occurrence of each pair of class in `rows` and
+// `cols`.
+// See CrossTab for more information.
+//
+func Ints64CrossTab(rows, cols, rowClasses, colClasses []int64) (
+	ct *CrossTable[int64], err error,
+) {
+	return CrossTab(rows, cols, rowClasses, colClasses)
 }
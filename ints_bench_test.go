@@ -0,0 +1,74 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"testing"
+)
+
+func benchInts(size int) []int {
+	r := rand.New(rand.NewSource(1))
+	d := make([]int, size)
+	for x := range d {
+		d[x] = r.Intn(size) - size/2
+	}
+	return d
+}
+
+func BenchmarkIntsInplaceMergesort(b *testing.B) {
+	in := benchInts(10000)
+	d := make([]int, len(in))
+	ids := make([]int, len(in))
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(d, in)
+		b.StartTimer()
+
+		numerus.IntsInplaceMergesort(d, ids, 0, len(d), true)
+	}
+}
+
+func BenchmarkIntsIndirectRadixSort(b *testing.B) {
+	in := benchInts(10000)
+	d := make([]int, len(in))
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(d, in)
+		b.StartTimer()
+
+		numerus.IntsIndirectRadixSort(d, true)
+	}
+}
+
+func BenchmarkIntsSort(b *testing.B) {
+	in := benchInts(10000)
+	d := make([]int, len(in))
+	ids := make([]int, len(in))
+
+	for _, c := range []struct {
+		name string
+		algo numerus.SortAlgorithm
+	}{
+		{"mergesort", numerus.SortMergesort},
+		{"pdqsort", numerus.SortPdqsort},
+		{"timsort", numerus.SortTimsort},
+	} {
+		opts := &numerus.SortOptions{Algorithm: c.algo}
+
+		b.Run(c.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				copy(d, in)
+				b.StartTimer()
+
+				numerus.IntsSort(d, ids, 0, len(d), true, opts)
+			}
+		})
+	}
+}
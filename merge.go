@@ -0,0 +1,100 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// Merge merge the already sorted slices `ds` into one sorted slice `out`,
+// using heap-based k-way merge.
+// Each slice in `ds` must be sorted in ascending order if `asc` is true, or
+// in descending order otherwise.
+//
+// For each element in `out`, `src` contains the index of slice in `ds` where
+// the element come from and `ids` contains the index of element in that
+// slice, so out[x] is equal to ds[src[x]][ids[x]].
+//
+// The merge is stable: equal elements is ordered by their slice index and
+// then by their index in slice.
+//
+// Example, given ds [[1 3 5] [2 3]] in ascending order, it will return
+// [1 2 3 3 5] as out, [0 1 0 1 0] as src, and [0 0 1 1 2] as ids.
+//
+func Merge[T Number](asc bool, ds ...[]T) (out []T, src, ids []int) {
+	return kwayMerge(ds, func(a, b T) int {
+		return compare(a, b, asc)
+	})
+}
+
+//
+// kwayMerge merge the sorted slices using comparison function `cmp`.
+// The heap contains the index of slice that still have element, ordered by
+// their current element.
+//
+func kwayMerge[T Number](ds [][]T, cmp func(a, b T) int) (
+	out []T, src, ids []int,
+) {
+	var (
+		n    int
+		pos  = make([]int, len(ds))
+		heap = make([]int, 0, len(ds))
+	)
+
+	for x, d := range ds {
+		n += len(d)
+		if len(d) > 0 {
+			heap = append(heap, x)
+		}
+	}
+
+	out = make([]T, 0, n)
+	src = make([]int, 0, n)
+	ids = make([]int, 0, n)
+
+	less := func(x, y int) bool {
+		a, b := heap[x], heap[y]
+		c := cmp(ds[a][pos[a]], ds[b][pos[b]])
+		if c != 0 {
+			return c < 0
+		}
+		return a < b
+	}
+	down := func(x int) {
+		for {
+			c := 2*x + 1
+			if c >= len(heap) {
+				return
+			}
+			if c+1 < len(heap) && less(c+1, c) {
+				c++
+			}
+			if !less(c, x) {
+				return
+			}
+			heap[x], heap[c] = heap[c], heap[x]
+			x = c
+		}
+	}
+
+	for x := len(heap)/2 - 1; x >= 0; x-- {
+		down(x)
+	}
+
+	for len(heap) > 0 {
+		s := heap[0]
+
+		out = append(out, ds[s][pos[s]])
+		src = append(src, s)
+		ids = append(ids, pos[s])
+
+		pos[s]++
+		if pos[s] == len(ds[s]) {
+			last := len(heap) - 1
+			heap[0] = heap[last]
+			heap = heap[:last]
+		}
+		down(0)
+	}
+
+	return out, src, ids
+}
@@ -0,0 +1,85 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	out, src, ids := numerus.IntsMerge(true, []int{1, 3, 5}, nil,
+		[]int{2, 3})
+
+	assert(t, []int{1, 2, 3, 3, 5}, out, true)
+	assert(t, []int{0, 2, 0, 2, 0}, src, true)
+	assert(t, []int{0, 0, 1, 1, 2}, ids, true)
+
+	out, src, ids = numerus.IntsMerge(false)
+
+	assert(t, []int{}, out, true)
+	assert(t, []int{}, src, true)
+	assert(t, []int{}, ids, true)
+}
+
+func TestMergeShards(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	in := make([]int64, 1000)
+	for x := range in {
+		in[x] = r.Int63n(100)
+	}
+
+	for _, asc := range []bool{true, false} {
+		// Sort each shard, and then merge them.
+		var (
+			shards   [][]int64
+			shardIds [][]int
+		)
+		for x := 0; x < len(in); x += 150 {
+			end := x + 150
+			if end > len(in) {
+				end = len(in)
+			}
+			shard := make([]int64, end-x)
+			copy(shard, in[x:end])
+
+			sortedIds := numerus.Ints64IndirectSort(shard, asc)
+			for y := range sortedIds {
+				sortedIds[y] += x
+			}
+
+			shards = append(shards, shard)
+			shardIds = append(shardIds, sortedIds)
+		}
+
+		out, src, ids := numerus.Ints64Merge(asc, shards...)
+
+		exp := make([]int64, len(in))
+		copy(exp, in)
+		expIds := numerus.Ints64IndirectSort(exp, asc)
+
+		assert(t, exp, out, true)
+		for x := range out {
+			// The merge of stable sorted shards is equal to
+			// stable sort of all data.
+			assert(t, expIds[x], shardIds[src[x]][ids[x]], true)
+		}
+	}
+}
+
+func TestFloats64Merge(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNLast)
+
+	out, src, ids := f.Merge(false,
+		[]float64{3, 1, nan},
+		[]float64{posInf, 2, negInf, nan},
+	)
+
+	assert(t, "[+Inf 3 2 1 -Inf NaN NaN]", fmt.Sprint(out), true)
+	assert(t, []int{1, 0, 1, 0, 1, 0, 1}, src, true)
+	assert(t, []int{0, 0, 1, 1, 2, 2, 3}, ids, true)
+}
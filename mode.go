@@ -0,0 +1,178 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"math/rand"
+)
+
+//
+// ModeTie define how Mode choose the mode when two or more values have the
+// same maximum count.
+//
+type ModeTie int
+
+// List of tie policy for Mode.
+const (
+	// ModeFirst choose the value that appear first in data.
+	ModeFirst ModeTie = iota
+
+	// ModeSmallest choose the smallest value.
+	ModeSmallest
+
+	// ModeLargest choose the largest value.
+	ModeLargest
+
+	// ModeRandom choose one of the value randomly, using
+	// ModeOptions.Rand.
+	ModeRandom
+
+	// ModeAll return all of the values, ordered by their first
+	// appearance in data.
+	ModeAll
+)
+
+//
+// ModeOptions define the options for Mode.
+//
+type ModeOptions struct {
+	// Tie define how to choose the mode if two or more values have the
+	// same count.
+	// Default to ModeFirst.
+	Tie ModeTie
+
+	// Rand is the source of random number for ModeRandom.
+	// If its nil, the default source from package math/rand is used.
+	Rand *rand.Rand
+}
+
+//
+// Mode return the most frequent value in `d` and their count.
+// The list of values is discovered from the data itself.
+//
+// If there are two or more values with the same count, only one of them is
+// returned based on the tie policy in `opts`, except for ModeAll.
+// If `opts` is nil, it will use the default options.
+// If `d` is empty, it will return nil, 0, and false.
+//
+// All NaN in data are counted as one value, and considered larger than any
+// other values on ModeSmallest and ModeLargest.
+//
+// Example, given data [1 2 2 3 3] it will return [2] with ModeFirst, [3]
+// with ModeLargest, and [2 3] with ModeAll; all of them with count 2.
+//
+func Mode[T Number](d []T, opts *ModeOptions) (
+	modes []T, count int, ok bool,
+) {
+	ft := newFreqTable(d)
+
+	counts := make([]int, len(ft.freqs))
+	for x, f := range ft.freqs {
+		counts[x] = f.Count
+	}
+
+	modes, count = selectModes(ft.freqs, counts, opts, compareNaNLast[T])
+
+	return modes, count, len(modes) > 0
+}
+
+//
+// ModeWeighted return the value in `d` with the maximum total weight, where
+// each value `d[x]` has weight `w[x]`, and their total weight.
+// It can be used to compute weighted majority vote.
+//
+// If the length of `d` and `w` is not equal, it will return an error that
+// match ErrLengthMismatch.
+// If one of the weight is negative or NaN, it will return an error that
+// match ErrNegativeWeight.
+//
+// See Mode for more information.
+//
+func ModeWeighted[T Number](d []T, w []float64, opts *ModeOptions) (
+	modes []T, weight float64, err error,
+) {
+	const fn = "numerus: ModeWeighted"
+
+	ft, err := newWeightedFreqTable(fn, d, w)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	modes, weight = selectModes(ft.freqs, ft.weights, opts,
+		compareNaNLast[T])
+
+	return modes, weight, nil
+}
+
+//
+// selectModes return the values in `freqs` with the maximum `counts`, where
+// the tie is resolved using the tie policy in `opts` and the comparison
+// function `cmp`.
+//
+func selectModes[T Number, C int | float64](freqs []Frequency[T], counts []C,
+	opts *ModeOptions, cmp func(a, b T) int,
+) (modes []T, best C) {
+	if opts == nil {
+		opts = &ModeOptions{}
+	}
+
+	var cands []int
+	for x, c := range counts {
+		switch {
+		case len(cands) == 0 || c > best:
+			best = c
+			cands = append(cands[:0], x)
+		case c == best:
+			cands = append(cands, x)
+		}
+	}
+	if len(cands) == 0 {
+		return nil, best
+	}
+
+	pick := cands[0]
+
+	switch opts.Tie {
+	case ModeSmallest, ModeLargest:
+		for _, x := range cands[1:] {
+			c := cmp(freqs[x].Value, freqs[pick].Value)
+			if (opts.Tie == ModeSmallest && c < 0) ||
+				(opts.Tie == ModeLargest && c > 0) {
+				pick = x
+			}
+		}
+	case ModeRandom:
+		if opts.Rand != nil {
+			pick = cands[opts.Rand.Intn(len(cands))]
+		} else {
+			pick = cands[rand.Intn(len(cands))]
+		}
+	case ModeAll:
+		modes = make([]T, len(cands))
+		for y, x := range cands {
+			modes[y] = freqs[x].Value
+		}
+		return modes, best
+	}
+
+	return []T{freqs[pick].Value}, best
+}
+
+//
+// compareNaNLast compare two numbers in ascending order, where NaN is
+// larger than any other values.
+//
+func compareNaNLast[T Number](a, b T) int {
+	aNaN, bNaN := isNaN(a), isNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return 1
+	case bNaN:
+		return -1
+	}
+	return compare(a, b, true)
+}
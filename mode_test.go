@@ -0,0 +1,122 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"testing"
+)
+
+func TestMode(t *testing.T) {
+	d := []int{3, 1, 2, 2, 3, 1, 0}
+
+	cases := []struct {
+		tie numerus.ModeTie
+		exp []int
+	}{
+		{numerus.ModeFirst, []int{3}},
+		{numerus.ModeSmallest, []int{1}},
+		{numerus.ModeLargest, []int{3}},
+		{numerus.ModeAll, []int{3, 1, 2}},
+	}
+
+	for _, c := range cases {
+		got, count, ok := numerus.IntsMode(d,
+			&numerus.ModeOptions{Tie: c.tie})
+
+		assert(t, c.exp, got, true)
+		assert(t, 2, count, true)
+		assert(t, true, ok, true)
+	}
+
+	got, count, ok := numerus.Ints64Mode(nil, nil)
+
+	assert(t, 0, len(got), true)
+	assert(t, 0, count, true)
+	assert(t, false, ok, true)
+}
+
+func TestMode_Random(t *testing.T) {
+	d := []int{3, 1, 2, 2, 3, 1, 0}
+	opts := &numerus.ModeOptions{
+		Tie:  numerus.ModeRandom,
+		Rand: rand.New(rand.NewSource(1)),
+	}
+	seen := make(map[int]bool)
+
+	for x := 0; x < 50; x++ {
+		got, count, _ := numerus.IntsMode(d, opts)
+
+		assert(t, 1, len(got), true)
+		assert(t, 2, count, true)
+		seen[got[0]] = true
+	}
+
+	assert(t, map[int]bool{1: true, 2: true, 3: true}, seen, true)
+}
+
+func TestFloats64Mode(t *testing.T) {
+	d := []float64{nan, 1, 1, nan, 2}
+	opts := &numerus.ModeOptions{Tie: numerus.ModeSmallest}
+
+	f := numerus.Floats64With(numerus.NaNFirst)
+
+	got, count, _ := f.Mode(d, opts)
+
+	assert(t, "[NaN]", fmt.Sprint(got), true)
+	assert(t, 2, count, true)
+
+	f = numerus.Floats64With(numerus.NaNLast)
+
+	got, _, _ = f.Mode(d, opts)
+
+	assert(t, []float64{1}, got, true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	got, count, _ = f.Mode(d, nil)
+
+	assert(t, []float64{1}, got, true)
+	assert(t, 2, count, true)
+}
+
+func TestModeWeighted(t *testing.T) {
+	d := []int{1, 2, 2, 3}
+	w := []float64{0.9, 0.3, 0.4, 0.9}
+
+	got, weight, err := numerus.IntsModeWeighted(d, w, nil)
+
+	assert(t, nil, err, true)
+	assert(t, []int{1}, got, true)
+	assert(t, 0.9, weight, true)
+
+	got, _, _ = numerus.IntsModeWeighted(d, w,
+		&numerus.ModeOptions{Tie: numerus.ModeAll})
+
+	assert(t, []int{1, 3}, got, true)
+
+	_, _, err = numerus.Ints64ModeWeighted([]int64{1}, w, nil)
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+
+	_, _, err = numerus.Floats64ModeWeighted([]float64{1, 2},
+		[]float64{1, -1}, nil)
+
+	assert(t, "numerus: Floats64ModeWeighted: index 1: negative "+
+		"weight: -1", err.Error(), true)
+	assert(t, true, errors.Is(err, numerus.ErrNegativeWeight), true)
+
+	f := numerus.Floats64With(numerus.NaNSkip)
+
+	modes, weight, err := f.ModeWeighted(
+		[]float64{nan, 1, 2}, []float64{5, 1, 2}, nil)
+
+	assert(t, nil, err, true)
+	assert(t, []float64{2}, modes, true)
+	assert(t, float64(2), weight, true)
+}
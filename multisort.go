@@ -0,0 +1,108 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"fmt"
+)
+
+//
+// SortKey define one column that is used to order the rows in
+// MultiIndirectSort.
+//
+type SortKey struct {
+	// Column is the values of column, must be one of []int, []int64,
+	// or []float64.
+	Column interface{}
+
+	// Asc if its true the column is ordered in ascending order, otherwise
+	// in descending order.
+	Asc bool
+}
+
+//
+// MultiIndirectSort return the row permutation that order the rows by the
+// first key, and then by the second key if the first key is equal, and so
+// on.
+// The columns in `keys` is not modified.
+//
+// All columns must have the same length as the first column, otherwise it
+// will return *LengthError, which match ErrLengthMismatch.
+// If the type of column is not []int, []int64, or []float64, it will return
+// ErrInvalidType.
+//
+// The sort is stable, rows with equal keys keep their original order.
+// NaN in []float64 column is placed at the end, in both ascending and
+// descending order.
+//
+// Example, given column A [1 0 1 0] in ascending and column B [0.1 0.2 0.3
+// 0.4] in descending order, it will return [3 1 2 0].
+//
+func MultiIndirectSort(keys ...SortKey) (sortedIdx []int, err error) {
+	return Floats64With(NaNPropagate).MultiIndirectSort(keys...)
+}
+
+//
+// MultiIndirectSort order the rows like the MultiIndirectSort function, except
+// that NaN in []float64 column is placed at the beginning if the policy of `f`
+// is NaNFirst.
+//
+func (f Floats64Ops) MultiIndirectSort(keys ...SortKey) (
+	sortedIdx []int, err error,
+) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	const fn = "numerus: MultiIndirectSort"
+
+	var (
+		n    = -1
+		cmps = make([]func(i, j int) int, len(keys))
+	)
+
+	for x, key := range keys {
+		var l int
+		asc := key.Asc
+
+		switch col := key.Column.(type) {
+		case []int:
+			l = len(col)
+			cmps[x] = func(i, j int) int {
+				return compare(col[i], col[j], asc)
+			}
+		case []int64:
+			l = len(col)
+			cmps[x] = func(i, j int) int {
+				return compare(col[i], col[j], asc)
+			}
+		case []float64:
+			l = len(col)
+			cmps[x] = func(i, j int) int {
+				return f.compare(col[i], col[j], asc)
+			}
+		default:
+			return nil, fmt.Errorf("%s: key %d: %w: %T", fn, x,
+				ErrInvalidType, key.Column)
+		}
+
+		if n < 0 {
+			n = l
+		} else if l != n {
+			return nil, &LengthError{Func: fn, Len: l, Exp: n}
+		}
+	}
+
+	sortedIdx = IndirectSortFunc(n, func(i, j int) bool {
+		for _, cmp := range cmps {
+			if c := cmp(i, j); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	return sortedIdx, nil
+}
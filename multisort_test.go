@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestMultiIndirectSort(t *testing.T) {
+	a := []int{1, 0, 1, 0}
+	b := []float64{0.1, 0.2, 0.3, 0.4}
+
+	got, err := numerus.MultiIndirectSort(
+		numerus.SortKey{Column: a, Asc: true},
+		numerus.SortKey{Column: b, Asc: false},
+	)
+
+	assert(t, nil, err, true)
+	assert(t, []int{3, 1, 2, 0}, got, true)
+	assert(t, []int{1, 0, 1, 0}, a, true)
+}
+
+func TestFloats64OpsMultiIndirectSort(t *testing.T) {
+	a := []int{0, 0, 1, 1}
+	b := []float64{0.1, nan, 0.3, nan}
+
+	for _, c := range []struct {
+		policy numerus.NaNPolicy
+		exp    []int
+	}{
+		{numerus.NaNFirst, []int{1, 0, 3, 2}},
+		{numerus.NaNLast, []int{0, 1, 2, 3}},
+	} {
+		f := numerus.Floats64With(c.policy)
+
+		got, err := f.MultiIndirectSort(
+			numerus.SortKey{Column: a, Asc: true},
+			numerus.SortKey{Column: b, Asc: true},
+		)
+
+		assert(t, nil, err, true)
+		assert(t, c.exp, got, true)
+	}
+}
+
+func TestMultiIndirectSortMixed(t *testing.T) {
+	a := []int64{2, 1, 2, 1, 2, 1}
+	b := []float64{0.5, 0.5, 0.1, 0.5, 0.5, 0.1}
+	c := []int{3, 2, 1, 1, 2, 3}
+
+	got, err := numerus.MultiIndirectSort(
+		numerus.SortKey{Column: a, Asc: false},
+		numerus.SortKey{Column: b, Asc: true},
+		numerus.SortKey{Column: c, Asc: true},
+	)
+
+	assert(t, nil, err, true)
+	assert(t, []int{2, 4, 0, 5, 3, 1}, got, true)
+
+	// Rows with equal keys keep their order.
+	got, _ = numerus.MultiIndirectSort(
+		numerus.SortKey{Column: a, Asc: true},
+		numerus.SortKey{Column: b, Asc: true},
+	)
+
+	assert(t, []int{5, 1, 3, 2, 0, 4}, got, true)
+}
+
+func TestMultiIndirectSortError(t *testing.T) {
+	_, err := numerus.MultiIndirectSort(
+		numerus.SortKey{Column: []int{1, 2}},
+		numerus.SortKey{Column: []int{1}},
+	)
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+
+	var lerr *numerus.LengthError
+
+	assert(t, true, errors.As(err, &lerr), true)
+	assert(t, 1, lerr.Len, true)
+	assert(t, 2, lerr.Exp, true)
+
+	_, err = numerus.MultiIndirectSort(
+		numerus.SortKey{Column: []string{"a"}},
+	)
+
+	assert(t, true, errors.Is(err, numerus.ErrInvalidType), true)
+}
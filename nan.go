@@ -0,0 +1,140 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// NaNPolicy define how the methods of Floats64Ops handle NaN value in data.
+//
+// The positive and negative infinity does not need special handling, they are
+// ordered as the lowest and highest value.
+// Negative zero is equal to positive zero, so their order after sorting is
+// the same as their original order, except on radix sort where -0 is placed
+// before +0 in ascending order.
+//
+type NaNPolicy int
+
+// List of NaN policy.
+const (
+	// NaNPropagate make FindMax, FindMin, and Sum return NaN if data
+	// contains NaN.
+	// In sorting, NaN is placed at the end of data.
+	// In counting, NaN is equal to NaN.
+	NaNPropagate NaNPolicy = iota
+
+	// NaNSkip make all functions ignore the NaN, as if its not exist in
+	// data.
+	// In sorting, NaN is placed at the end of data.
+	// In counting, NaN is never counted.
+	NaNSkip
+
+	// NaNFirst place all NaN at the beginning of data after sorting, in
+	// both ascending and descending order.
+	// FindMax, FindMin, and Sum ignore the NaN, while in counting NaN is
+	// equal to NaN.
+	NaNFirst
+
+	// NaNLast place all NaN at the end of data after sorting, in both
+	// ascending and descending order.
+	// FindMax, FindMin, and Sum ignore the NaN, while in counting NaN is
+	// equal to NaN.
+	NaNLast
+)
+
+//
+// Floats64Ops contains the Floats64 functions that handle NaN following their
+// own NaN policy.
+// The Floats64 functions always use NaNPropagate; use Floats64With to get
+// the same functions with other policy, for example Floats64Ops.Sum of
+// Floats64With(NaNSkip) is equal to Floats64Sum that ignore the NaN.
+//
+// The zero value use NaNPropagate.
+// It is safe to use different Floats64Ops concurrently.
+//
+type Floats64Ops struct {
+	policy NaNPolicy
+}
+
+//
+// Floats64With return the Floats64 functions that handle NaN following the
+// `policy`.
+//
+// Example, to sum the data while ignoring NaN,
+//
+//	sum := Floats64With(NaNSkip).Sum(d)
+//
+func Floats64With(policy NaNPolicy) Floats64Ops {
+	return Floats64Ops{policy: policy}
+}
+
+//
+// Policy return the NaN policy of `f`.
+//
+func (f Floats64Ops) Policy() NaNPolicy {
+	return f.policy
+}
+
+//
+// partitionNaN move all NaN in `d[l:r]` to the end of range, or to the
+// beginning of range if `first` is true, while keeping the order of the
+// other values.
+// The index `idx` is moved in step with `d`.
+// It will return the new range of non-NaN values.
+//
+func partitionNaN[T Number](d []T, idx []int, l, r int, first bool) (
+	nl, nr int,
+) {
+	var (
+		nans   []T
+		nanIds []int
+		hasIdx = len(idx) >= r
+	)
+
+	if !first {
+		w := l
+		for x := l; x < r; x++ {
+			if isNaN(d[x]) {
+				nans = append(nans, d[x])
+				if hasIdx {
+					nanIds = append(nanIds, idx[x])
+				}
+				continue
+			}
+			d[w] = d[x]
+			if hasIdx {
+				idx[w] = idx[x]
+			}
+			w++
+		}
+		copy(d[w:r], nans)
+		if hasIdx {
+			copy(idx[w:r], nanIds)
+		}
+		return l, w
+	}
+
+	w := r - 1
+	for x := r - 1; x >= l; x-- {
+		if isNaN(d[x]) {
+			nans = append(nans, d[x])
+			if hasIdx {
+				nanIds = append(nanIds, idx[x])
+			}
+			continue
+		}
+		d[w] = d[x]
+		if hasIdx {
+			idx[w] = idx[x]
+		}
+		w--
+	}
+	// The NaN is collected in reverse order.
+	for x, y := l, len(nans)-1; y >= 0; x, y = x+1, y-1 {
+		d[x] = nans[y]
+		if hasIdx {
+			idx[x] = nanIds[y]
+		}
+	}
+	return w + 1, r
+}
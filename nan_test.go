@@ -0,0 +1,204 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math"
+	"sync"
+	"testing"
+)
+
+var (
+	nan     = math.NaN()
+	posInf  = math.Inf(1)
+	negInf  = math.Inf(-1)
+	negZero = math.Copysign(0, -1)
+
+	dFloats64NaN = []float64{3, nan, posInf, -1, negZero, nan, negInf, 0}
+)
+
+func TestFloats64NaNPolicy(t *testing.T) {
+	d := []float64{1, nan, 2}
+
+	// The Floats64 functions always propagate the NaN.
+	assert(t, true, math.IsNaN(numerus.Floats64Sum(d)), true)
+
+	f := numerus.Floats64With(numerus.NaNSkip)
+
+	assert(t, numerus.NaNSkip, f.Policy(), true)
+	assert(t, float64(3), f.Sum(d), true)
+
+	f = numerus.Floats64Ops{}
+
+	assert(t, numerus.NaNPropagate, f.Policy(), true)
+	assert(t, true, math.IsNaN(f.Sum(d)), true)
+}
+
+func TestFloats64WithConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for _, policy := range []numerus.NaNPolicy{
+		numerus.NaNFirst, numerus.NaNLast,
+	} {
+		wg.Add(1)
+		go func(f numerus.Floats64Ops) {
+			defer wg.Done()
+
+			exp := "[NaN NaN -Inf -1 -0 0 3 +Inf]"
+			if f.Policy() == numerus.NaNLast {
+				exp = "[-Inf -1 -0 0 3 +Inf NaN NaN]"
+			}
+			for x := 0; x < 100; x++ {
+				d := make([]float64, len(dFloats64NaN))
+				copy(d, dFloats64NaN)
+
+				f.IndirectSort(d, true)
+
+				if got := fmt.Sprint(d); got != exp {
+					t.Errorf("policy %d: got %s, want %s",
+						f.Policy(), got, exp)
+					return
+				}
+			}
+		}(numerus.Floats64With(policy))
+	}
+	wg.Wait()
+}
+
+func TestFloats64FindMaxNaN(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	gotv, goti, gotok := f.FindMax(dFloats64NaN)
+
+	assert(t, true, math.IsNaN(gotv), true)
+	assert(t, 1, goti, true)
+	assert(t, true, gotok, true)
+
+	for _, policy := range []numerus.NaNPolicy{
+		numerus.NaNSkip, numerus.NaNFirst, numerus.NaNLast,
+	} {
+		f = numerus.Floats64With(policy)
+
+		gotv, goti, gotok = f.FindMax(dFloats64NaN)
+
+		assert(t, posInf, gotv, true)
+		assert(t, 2, goti, true)
+		assert(t, true, gotok, true)
+
+		gotv, goti, gotok = f.FindMin(dFloats64NaN)
+
+		assert(t, negInf, gotv, true)
+		assert(t, 6, goti, true)
+		assert(t, true, gotok, true)
+
+		gotv, goti, gotok = f.FindMin([]float64{nan, nan})
+
+		assert(t, float64(-1), gotv, true)
+		assert(t, -1, goti, true)
+		assert(t, false, gotok, true)
+	}
+}
+
+func TestFloats64SumNaN(t *testing.T) {
+	d := []float64{1, nan, 2}
+
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	assert(t, true, math.IsNaN(f.Sum(d)), true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	assert(t, float64(3), f.Sum(d), true)
+}
+
+func TestFloats64CountNaN(t *testing.T) {
+	classes := []float64{nan, 0, posInf}
+
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	got := f.Counts(dFloats64NaN, classes)
+
+	// Negative zero is equal to zero.
+	assert(t, []int{2, 2, 1}, got, true)
+
+	gotv, _ := f.MaxCountOf(dFloats64NaN, classes)
+
+	assert(t, true, math.IsNaN(gotv), true)
+	assert(t, true, f.IsExist(dFloats64NaN, nan), true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	got = f.Counts(dFloats64NaN, classes)
+
+	assert(t, []int{0, 2, 1}, got, true)
+
+	gotv, _ = f.MaxCountOf(dFloats64NaN, classes)
+
+	assert(t, float64(0), gotv, true)
+	assert(t, false, f.IsExist(dFloats64NaN, nan), true)
+}
+
+func TestFloats64IndirectSortNaN(t *testing.T) {
+	cases := []struct {
+		policy numerus.NaNPolicy
+		asc    bool
+		exp    string
+		expIds []int
+	}{{
+		policy: numerus.NaNPropagate,
+		asc:    true,
+		exp:    "[-Inf -1 -0 0 3 +Inf NaN NaN]",
+		expIds: []int{6, 3, 4, 7, 0, 2, 1, 5},
+	}, {
+		policy: numerus.NaNSkip,
+		asc:    false,
+		exp:    "[+Inf 3 -0 0 -1 -Inf NaN NaN]",
+		expIds: []int{2, 0, 4, 7, 3, 6, 1, 5},
+	}, {
+		policy: numerus.NaNFirst,
+		asc:    true,
+		exp:    "[NaN NaN -Inf -1 -0 0 3 +Inf]",
+		expIds: []int{1, 5, 6, 3, 4, 7, 0, 2},
+	}, {
+		policy: numerus.NaNLast,
+		asc:    false,
+		exp:    "[+Inf 3 -0 0 -1 -Inf NaN NaN]",
+		expIds: []int{2, 0, 4, 7, 3, 6, 1, 5},
+	}}
+
+	for _, c := range cases {
+		f := numerus.Floats64With(c.policy)
+
+		d := make([]float64, len(dFloats64NaN))
+		copy(d, dFloats64NaN)
+
+		gotIds := f.IndirectSort(d, c.asc)
+
+		assert(t, c.exp, fmt.Sprint(d), true)
+		assert(t, c.expIds, gotIds, true)
+
+		copy(d, dFloats64NaN)
+
+		f.RadixSort(d, nil, c.asc)
+
+		// Radix sort always put -0 before 0 on ascending.
+		assert(t, 2, f.Count(d, 0), true)
+		assert(t, c.exp[:5], fmt.Sprint(d)[:5], true)
+	}
+}
+
+func TestFloats64InsertionSortNaN(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNFirst)
+
+	d := []float64{nan, 2, nan, 1}
+	ids := []int{0, 1, 2, 3}
+
+	f.InsertionSort(d, ids, 0, len(d), false)
+
+	assert(t, "[NaN NaN 2 1]", fmt.Sprint(d), true)
+	assert(t, []int{0, 2, 1, 3}, ids, true)
+}
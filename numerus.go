@@ -6,17 +6,68 @@
 // Package numerus provide miscellaneous functions for working with integer,
 // float, slice of integer, and slice of floats.
 //
+// Most of slice functions are implemented once as type-parameterized
+// function over Number constraint, e.g. FindMax or InplaceMergesort.
+// The functions with prefix Ints, Ints64, and Floats64 are wrappers for
+// specific slice type.
+//
 // Currently it have function to,
-// - sort slice of floats using in-place mergesort algorithm.
-// - sort slice of integer/floats by predefined index
-// - count number of value occurence in slice of integer/float
+// - sort slice using in-place mergesort, pdqsort, timsort, or radix sort
+// - sort large slice concurrently using parallel mergesort
+// - sort slice of integer/floats by predefined index, function, or key
+// - sort index of rows by multiple columns
+// - sort data that does not fit in memory using external sort
+// - select the n-th element and median without fully sorting the data
+// - partially sort the k largest or smallest values
+// - return the index that would sort the data (argsort)
+// - apply, invert, compose, and validate permutations
+// - merge already sorted slices
+// - search sorted slice for lower/upper bound, range, or nearest value
+// - check if data is sorted and find the natural runs
+// - rank the data
+// - count number of value occurence in slice, with or without weight
+// - compute the frequency, mode, and cross tabulation of values
+// - compute the impurity, like entropy and gini, and find the best split
 // - find minimum or maximum value in slice of integer/float
 // - sum slice of integer/float
+// - handle NaN in slice of floats following the NaN policy
 //
 package numerus
 
 const (
 	// SortThreshold when the data less than SortThreshold, insertion sort
 	// will be used to replace mergesort.
+	// InplaceMergesort, InplaceMergesortParallel, InplaceMergesortFunc,
+	// and NthElement always use this value.
+	// It is also the default value of SortOptions.Threshold, which can be
+	// changed per call only in Sort.
 	SortThreshold = 7
+
+	// ParallelThreshold when the data less than ParallelThreshold, the
+	// parallel merge-sort will sort the data sequentially.
+	ParallelThreshold = 4096
 )
+
+//
+// Integer is a constraint that permits any integer type, including the named
+// type with integer as their underlying type.
+//
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+//
+// Float is a constraint that permits any floating-point type, including the
+// named type with float as their underlying type.
+//
+type Float interface {
+	~float32 | ~float64
+}
+
+//
+// Number is a constraint that permits any integer or floating-point type.
+//
+type Number interface {
+	Integer | Float
+}
@@ -0,0 +1,126 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// TopK return the `k` largest values in `d` and their index, sorted in
+// descending order.
+// Equal values are ordered by their index.
+//
+// The data `d` is not modified and not fully sorted; it use bounded heap
+// with size `k`, so the time complexity is O(n log k).
+// If `k` is less or equal to zero, it will return nil.
+// If `k` is greater than length of data, all values will be returned.
+//
+// Example, given data [5 1 4 2 5] and k 3, it will return [5 5 4] as values
+// and [0 4 2] as index.
+//
+func TopK[T Number](d []T, k int) (vals []T, ids []int) {
+	return partialSort(d, k, func(a, b T) bool { return a > b }, nil)
+}
+
+//
+// BottomK return the `k` smallest values in `d` and their index, sorted in
+// ascending order.
+// Equal values are ordered by their index.
+//
+// See TopK for more information.
+//
+func BottomK[T Number](d []T, k int) (vals []T, ids []int) {
+	return partialSort(d, k, func(a, b T) bool { return a < b }, nil)
+}
+
+//
+// partialSort return the first `k` values and their index from `d` if `d`
+// were sorted using `before` function, where `before(a, b)` return true if
+// `a` should be placed before `b`.
+// Value that return true in `skip` function is ignored.
+//
+// The values is kept in heap where the root is the value that will be
+// placed last.
+//
+func partialSort[T Number](d []T, k int, before func(a, b T) bool,
+	skip func(T) bool,
+) (vals []T, ids []int) {
+	if k <= 0 || len(d) == 0 {
+		return nil, nil
+	}
+	if k > len(d) {
+		k = len(d)
+	}
+
+	vals = make([]T, 0, k)
+	ids = make([]int, 0, k)
+
+	// after return true if heap element at `x` should be placed after
+	// heap element at `y`.
+	after := func(x, y int) bool {
+		if before(vals[y], vals[x]) {
+			return true
+		}
+		if before(vals[x], vals[y]) {
+			return false
+		}
+		return ids[x] > ids[y]
+	}
+	swap := func(x, y int) {
+		vals[x], vals[y] = vals[y], vals[x]
+		ids[x], ids[y] = ids[y], ids[x]
+	}
+	down := func(x, n int) {
+		for {
+			c := 2*x + 1
+			if c >= n {
+				return
+			}
+			if c+1 < n && after(c+1, c) {
+				c++
+			}
+			if !after(c, x) {
+				return
+			}
+			swap(x, c)
+			x = c
+		}
+	}
+
+	for x, v := range d {
+		if skip != nil && skip(v) {
+			continue
+		}
+		if len(vals) < k {
+			vals = append(vals, v)
+			ids = append(ids, x)
+
+			// Sift up the new element.
+			for c := len(vals) - 1; c > 0; {
+				p := (c - 1) / 2
+				if !after(c, p) {
+					break
+				}
+				swap(c, p)
+				c = p
+			}
+			continue
+		}
+		// Index `x` is always greater than any index in heap, so
+		// equal value is placed after the root.
+		if !before(v, vals[0]) {
+			continue
+		}
+		vals[0] = v
+		ids[0] = x
+		down(0, k)
+	}
+
+	// Move the root, the last placed value, to the end of heap one by
+	// one.
+	for n := len(vals) - 1; n > 0; n-- {
+		swap(0, n)
+		down(0, n)
+	}
+
+	return vals, ids
+}
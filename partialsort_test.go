@@ -0,0 +1,93 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	d := []int{5, 1, 4, 2, 5}
+	org := fmt.Sprint(d)
+
+	gotv, goti := numerus.TopK(d, 3)
+
+	assert(t, []int{5, 5, 4}, gotv, true)
+	assert(t, []int{0, 4, 2}, goti, true)
+	assert(t, org, fmt.Sprint(d), true)
+
+	gotv, goti = numerus.BottomK(d, 3)
+
+	assert(t, []int{1, 2, 4}, gotv, true)
+	assert(t, []int{1, 3, 2}, goti, true)
+	assert(t, org, fmt.Sprint(d), true)
+}
+
+func TestTopKOutOfRange(t *testing.T) {
+	gotv, goti := numerus.IntsTopK(dInts[1], 0)
+
+	assert(t, 0, len(gotv), true)
+	assert(t, 0, len(goti), true)
+
+	gotv, goti = numerus.IntsBottomK(dInts[1], 100)
+
+	assert(t, dIntsSorted[1], gotv, true)
+	assert(t, []int{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}, goti, true)
+}
+
+func TestTopKRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	in := make([]int64, 500)
+	for x := range in {
+		in[x] = r.Int63n(50)
+	}
+
+	for _, k := range []int{1, 7, 100, 500} {
+		gotv, goti := numerus.Ints64TopK(in, k)
+
+		d := make([]int64, len(in))
+		copy(d, in)
+		numerus.Ints64IndirectSort(d, false)
+
+		assert(t, d[:k], gotv, true)
+		for x := range goti {
+			assert(t, in[goti[x]], gotv[x], true)
+			if x > 0 && gotv[x] == gotv[x-1] {
+				assert(t, true, goti[x] > goti[x-1], true)
+			}
+		}
+
+		gotv, _ = numerus.Ints64BottomK(in, k)
+		copy(d, in)
+		numerus.Ints64IndirectSort(d, true)
+
+		assert(t, d[:k], gotv, true)
+	}
+}
+
+func TestFloats64TopKNaN(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	gotv, goti := f.TopK(dFloats64NaN, 3)
+
+	assert(t, "[NaN NaN +Inf]", fmt.Sprint(gotv), true)
+	assert(t, []int{1, 5, 2}, goti, true)
+
+	maxv, maxi, _ := f.FindMax(dFloats64NaN)
+	gotv, goti = f.TopK(dFloats64NaN, 1)
+
+	assert(t, fmt.Sprint(maxv), fmt.Sprint(gotv[0]), true)
+	assert(t, maxi, goti[0], true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	gotv, goti = f.BottomK(dFloats64NaN, 10)
+
+	assert(t, "[-Inf -1 -0 0 3 +Inf]", fmt.Sprint(gotv), true)
+	assert(t, []int{6, 3, 4, 7, 0, 2}, goti, true)
+}
@@ -0,0 +1,352 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"math/bits"
+)
+
+//
+// sortedHint is the hint of data order that is found when choosing pivot.
+//
+type sortedHint int
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+//
+// xorshift is the pseudo random generator that is used to break the
+// patterns in data.
+//
+type xorshift uint64
+
+func (r *xorshift) next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+//
+// pdqsort sort the data using pattern-defeating quicksort, based on the
+// implementation in Go standard library.
+// Partition with length less or equal to `threshold` is sorted using
+// insertion sort.
+//
+func (s *sorter[T]) pdqsort(threshold int) {
+	n := len(s.d)
+	s.pdqsortRange(0, n, bits.Len(uint(n)), threshold)
+}
+
+func (s *sorter[T]) pdqsortRange(a, b, limit, threshold int) {
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+	)
+
+	for {
+		length := b - a
+
+		if length <= threshold {
+			s.insertionSort(a, b)
+			return
+		}
+
+		// Fall back to heap sort if too many bad choices were made.
+		if limit == 0 {
+			s.heapSort(a, b)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to break
+		// patterns.
+		if !wasBalanced {
+			s.breakPatterns(a, b)
+			limit--
+		}
+
+		pivot, hint := s.choosePivot(a, b)
+		if hint == decreasingHint {
+			s.reverse(a, b)
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if s.partialInsertionSort(a, b) {
+				return
+			}
+		}
+
+		// The pivot is equal to the pivot of the previous partition,
+		// which is placed before `a`, so the slice contains many
+		// duplicates.
+		if a > 0 && !s.less(a-1, pivot) {
+			a = s.partitionEqual(a, b, pivot)
+			continue
+		}
+
+		mid, alreadyPartitioned := s.partition(a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		// Recurse on the smaller side to limit the stack depth.
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			s.pdqsortRange(a, mid, limit, threshold)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			s.pdqsortRange(mid+1, b, limit, threshold)
+			b = mid
+		}
+	}
+}
+
+//
+// partition the `d[a:b]` into values less than pivot and values greater or
+// equal to pivot.
+// It will return the new index of pivot and true if the data was already
+// partitioned.
+//
+func (s *sorter[T]) partition(a, b, pivot int) (newpivot int, already bool) {
+	s.swap(a, pivot)
+	i, j := a+1, b-1
+
+	for i <= j && s.less(i, a) {
+		i++
+	}
+	for i <= j && !s.less(j, a) {
+		j--
+	}
+	if i > j {
+		s.swap(j, a)
+		return j, true
+	}
+	s.swap(i, j)
+	i++
+	j--
+
+	for {
+		for i <= j && s.less(i, a) {
+			i++
+		}
+		for i <= j && !s.less(j, a) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		s.swap(i, j)
+		i++
+		j--
+	}
+	s.swap(j, a)
+	return j, false
+}
+
+//
+// partitionEqual partition the `d[a:b]` into values equal to pivot followed
+// by values greater than pivot.
+// It assume that `d[a:b]` does not contains values less than pivot.
+//
+func (s *sorter[T]) partitionEqual(a, b, pivot int) (newpivot int) {
+	s.swap(a, pivot)
+	i, j := a+1, b-1
+
+	for {
+		for i <= j && !s.less(a, i) {
+			i++
+		}
+		for i <= j && s.less(a, j) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		s.swap(i, j)
+		i++
+		j--
+	}
+	return i
+}
+
+//
+// partialInsertionSort partially sort the `d[a:b]` by moving several out of
+// order elements.
+// It will return true if the data is sorted at the end.
+//
+func (s *sorter[T]) partialInsertionSort(a, b int) bool {
+	const (
+		maxSteps         = 5
+		shortestShifting = 50
+	)
+
+	i := a + 1
+	for step := 0; step < maxSteps; step++ {
+		for i < b && !s.less(i, i-1) {
+			i++
+		}
+		if i == b {
+			return true
+		}
+		if b-a < shortestShifting {
+			return false
+		}
+
+		s.swap(i, i-1)
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j >= 1; j-- {
+				if !s.less(j, j-1) {
+					break
+				}
+				s.swap(j, j-1)
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !s.less(j, j-1) {
+					break
+				}
+				s.swap(j, j-1)
+			}
+		}
+	}
+	return false
+}
+
+//
+// breakPatterns scatter some elements around in `d[a:b]` to break the
+// patterns that might cause imbalanced partitions.
+//
+func (s *sorter[T]) breakPatterns(a, b int) {
+	length := b - a
+	if length < 8 {
+		return
+	}
+
+	random := xorshift(length)
+	modulus := uint(1) << bits.Len(uint(length))
+
+	idx := a + (length/4)*2 - 1
+	for i := 0; i < 3; i++ {
+		other := int(uint(random.next()) & (modulus - 1))
+		if other >= length {
+			other -= length
+		}
+		s.swap(idx-1+i, a+other)
+	}
+}
+
+//
+// choosePivot choose the pivot in `d[a:b]` using median of three, or using
+// Tukey ninther for large data.
+// The hint is increasing if the sampled elements is already in order, or
+// decreasing if the sampled elements is in reverse order.
+//
+func (s *sorter[T]) choosePivot(a, b int) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	var (
+		l     = b - a
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			i = s.medianAdjacent(i, &swaps)
+			j = s.medianAdjacent(j, &swaps)
+			k = s.medianAdjacent(k, &swaps)
+		}
+		j = s.median(i, j, k, &swaps)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+//
+// order2 return the index `a` and `b` ordered by their value.
+//
+func (s *sorter[T]) order2(a, b int, swaps *int) (int, int) {
+	if s.less(b, a) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+//
+// median return the index of median value between `a`, `b`, and `c`.
+//
+func (s *sorter[T]) median(a, b, c int, swaps *int) int {
+	a, b = s.order2(a, b, swaps)
+	b, c = s.order2(b, c, swaps)
+	_, b = s.order2(a, b, swaps)
+	return b
+}
+
+//
+// medianAdjacent return the index of median value between `a-1`, `a`, and
+// `a+1`.
+//
+func (s *sorter[T]) medianAdjacent(a int, swaps *int) int {
+	return s.median(a-1, a, a+1, swaps)
+}
+
+//
+// heapSort sort the `d[a:b]` using heap sort.
+//
+func (s *sorter[T]) heapSort(a, b int) {
+	first := a
+	hi := b - a
+
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		s.siftDown(i, hi, first)
+	}
+	for i := hi - 1; i >= 0; i-- {
+		s.swap(first, first+i)
+		s.siftDown(0, i, first)
+	}
+}
+
+func (s *sorter[T]) siftDown(lo, hi, first int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			return
+		}
+		if child+1 < hi && s.less(first+child, first+child+1) {
+			child++
+		}
+		if !s.less(first+root, first+child) {
+			return
+		}
+		s.swap(first+root, first+child)
+		root = child
+	}
+}
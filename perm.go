@@ -0,0 +1,138 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// PermIsValid will return true if `perm` is a valid permutation, where each
+// index from 0 to len(perm)-1 exist exactly once; otherwise it will return
+// false.
+//
+// The `perm` is not modified, so it is safe to use it concurrently with
+// other readers.
+//
+func PermIsValid(perm []int) bool {
+	seen := make([]bool, len(perm))
+	for _, v := range perm {
+		if v < 0 || v >= len(perm) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+//
+// PermApply reorder the slice `d` in place using permutation `perm`, so the
+// new d[i] is the old d[perm[i]].
+// It is equal to IntsSortByIndex and others SortByIndex functions, but
+// without allocating new slice.
+//
+// The permutation is applied by following each cycle in `perm`, while
+// marking the visited index in `perm` temporarily; so it is not safe to use
+// `perm` concurrently.
+// The `perm` must be a valid permutation with the same length as `d`,
+// otherwise it may panic.
+//
+// Example, given d [a b c] and perm [2 0 1], the result is [c a b].
+//
+func PermApply[E any](d []E, perm []int) {
+	PermApplyFunc(perm, func(i, j int) {
+		d[i], d[j] = d[j], d[i]
+	})
+}
+
+//
+// PermApplyColumns reorder each of slice in `cols` in place using
+// permutation `perm`.
+//
+// Each column must have the same length as `perm`, otherwise it will return
+// *LengthError, which match ErrLengthMismatch, and none of the columns is
+// changed.
+// See PermApply for more information.
+//
+func PermApplyColumns[E any](perm []int, cols ...[]E) error {
+	const fn = "numerus: PermApplyColumns"
+
+	for _, d := range cols {
+		if len(d) != len(perm) {
+			return &LengthError{
+				Func: fn,
+				Len:  len(d),
+				Exp:  len(perm),
+			}
+		}
+	}
+
+	PermApplyFunc(perm, func(i, j int) {
+		for _, d := range cols {
+			d[i], d[j] = d[j], d[i]
+		}
+	})
+	return nil
+}
+
+//
+// PermApplyFunc apply the permutation `perm` by calling `swap` for each
+// pair of index that need to be swapped.
+// It can be used to reorder several columns with different types at once, or
+// any data that is not a slice.
+// See PermApply for more information.
+//
+func PermApplyFunc(perm []int, swap func(i, j int)) {
+	for s := range perm {
+		if perm[s] < 0 {
+			// Already visited by previous cycle.
+			continue
+		}
+		j := s
+		for {
+			k := perm[j]
+			perm[j] = ^k
+			if k == s {
+				break
+			}
+			swap(j, k)
+			j = k
+		}
+	}
+
+	// Restore the marked index.
+	for x, v := range perm {
+		perm[x] = ^v
+	}
+}
+
+//
+// PermInvert return the inverse of permutation `perm`, where
+// inv[perm[i]] = i.
+// Applying the inverse after applying `perm` will restore the original order
+// of data.
+//
+// The `perm` must be a valid permutation, otherwise it may panic.
+//
+func PermInvert(perm []int) (inv []int) {
+	inv = make([]int, len(perm))
+	for x, v := range perm {
+		inv[v] = x
+	}
+	return inv
+}
+
+//
+// PermCompose return the permutation that equal to applying `p` and then
+// applying `q`, where composed[i] = p[q[i]].
+//
+// If length of `p` and `q` is not equal, it will return nil.
+//
+func PermCompose(p, q []int) (composed []int) {
+	if len(p) != len(q) {
+		return nil
+	}
+	composed = make([]int, len(q))
+	for x, v := range q {
+		composed[x] = p[v]
+	}
+	return composed
+}
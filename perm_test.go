@@ -0,0 +1,138 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"github.com/shuLhan/numerus"
+	"sync"
+	"testing"
+)
+
+func TestPermIsValid(t *testing.T) {
+	cases := []struct {
+		perm []int
+		exp  bool
+	}{
+		{nil, true},
+		{[]int{0}, true},
+		{[]int{2, 0, 1}, true},
+		{[]int{1, 1, 0}, false},
+		{[]int{0, 3, 1}, false},
+		{[]int{0, -1, 1}, false},
+	}
+
+	for _, c := range cases {
+		org := append([]int(nil), c.perm...)
+
+		assert(t, c.exp, numerus.PermIsValid(c.perm), true)
+		assert(t, org, c.perm, true)
+	}
+}
+
+func TestPermApply(t *testing.T) {
+	d := []string{"a", "b", "c", "d", "e"}
+	perm := []int{2, 0, 1, 4, 3}
+
+	numerus.PermApply(d, perm)
+
+	assert(t, []string{"c", "a", "b", "e", "d"}, d, true)
+	assert(t, []int{2, 0, 1, 4, 3}, perm, true)
+}
+
+func TestPermApply_SortByIndex(t *testing.T) {
+	for x := range dFloats64 {
+		d := make([]float64, len(dFloats64[x]))
+		copy(d, dFloats64[x])
+
+		ids := numerus.Floats64IndirectSort(d, true)
+
+		copy(d, dFloats64[x])
+
+		numerus.PermApply(d, ids)
+
+		assert(t, dFloats64Sorted[x], d, true)
+	}
+}
+
+func TestPermApplyColumns(t *testing.T) {
+	a := []int{3, 1, 2}
+	b := []int{30, 10, 20}
+	c := []float64{0.3, 0.1, 0.2}
+
+	perm := numerus.IntsIndirectSort(a, true)
+
+	err := numerus.PermApplyColumns(perm, b)
+	numerus.PermApplyFunc(perm, func(i, j int) {
+		c[i], c[j] = c[j], c[i]
+	})
+
+	assert(t, nil, err, true)
+	assert(t, []int{10, 20, 30}, b, true)
+	assert(t, []float64{0.1, 0.2, 0.3}, c, true)
+
+	// Short column should not change any of columns or `perm`.
+	short := []int{1, 2}
+	org := append([]int(nil), perm...)
+
+	err = numerus.PermApplyColumns(perm, b, short)
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+	assert(t, []int{10, 20, 30}, b, true)
+	assert(t, []int{1, 2}, short, true)
+	assert(t, org, perm, true)
+}
+
+func TestPermIsValidConcurrent(t *testing.T) {
+	perm := numerus.IntCreateSeq(0, 999)
+
+	var wg sync.WaitGroup
+	for x := 0; x < 4; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 10; y++ {
+				if !numerus.PermIsValid(perm) {
+					t.Error("PermIsValid: expecting true")
+				}
+				for z, v := range perm {
+					if v != z {
+						t.Errorf("perm[%d]: %d", z, v)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPermInvert(t *testing.T) {
+	in := []int{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}
+	d := make([]int, len(in))
+	copy(d, in)
+
+	perm := numerus.IntsIndirectSort(d, true)
+	inv := numerus.PermInvert(perm)
+
+	numerus.PermApply(d, inv)
+
+	assert(t, in, d, true)
+	assert(t, []int{0, 1, 2}, numerus.PermInvert([]int{0, 1, 2}), true)
+}
+
+func TestPermCompose(t *testing.T) {
+	p := []int{2, 0, 1}
+	q := []int{1, 2, 0}
+
+	d1 := []int{10, 20, 30}
+	numerus.PermApply(d1, p)
+	numerus.PermApply(d1, q)
+
+	d2 := []int{10, 20, 30}
+	numerus.PermApply(d2, numerus.PermCompose(p, q))
+
+	assert(t, d1, d2, true)
+	assert(t, []int(nil), numerus.PermCompose(p, q[:1]), true)
+}
@@ -0,0 +1,220 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"math"
+)
+
+//
+// RadixSort sort the slice of integer `d` using least significant digit
+// (LSD) radix sort, one byte for each pass.
+// The index slice `idx` is permuted in step with `d`; if its nil, only `d`
+// will be sorted.
+//
+// The sort is stable, equal elements keep their original order, in both
+// ascending and descending order.
+// Unlike InplaceMergesort, this function allocate temporary buffers with the
+// same size as `d` and `idx`.
+//
+func RadixSort[T Integer](d []T, idx []int, asc bool) {
+	if len(d) <= 1 {
+		return
+	}
+
+	keys := make([]uint64, len(d))
+	for x, v := range d {
+		keys[x] = radixKey(v, asc)
+	}
+
+	radixSortKeys(keys, d, idx)
+}
+
+//
+// IndirectRadixSort will sort the data using RadixSort and return the sorted
+// index.
+//
+func IndirectRadixSort[T Integer](d []T, asc bool) (sortedIdx []int) {
+	dlen := len(d)
+
+	sortedIdx = make([]int, dlen)
+	for i := 0; i < dlen; i++ {
+		sortedIdx[i] = i
+	}
+
+	RadixSort(d, sortedIdx, asc)
+
+	return
+}
+
+//
+// Floats64RadixSort sort the slice of float `d` using LSD radix sort and
+// permute `idx` in step with `d`; if `idx` is nil, only `d` will be sorted.
+//
+// Each value is mapped to unsigned 64bit key that preserve their order:
+// the sign bit of positive value is flipped and all bits of negative value
+// is inverted.
+// As the result, -0 is placed before +0 in ascending order, and after +0 in
+// descending order.
+// NaN is placed at the end of data, in their original order.
+//
+// The sort is stable and allocate temporary buffers with the same size as
+// `d` and `idx`.
+//
+func Floats64RadixSort(d []float64, idx []int, asc bool) {
+	Floats64With(NaNPropagate).RadixSort(d, idx, asc)
+}
+
+//
+// RadixSort sort the data like Floats64RadixSort, except that NaN is placed at
+// the beginning of data if the policy of `f` is NaNFirst.
+//
+func (f Floats64Ops) RadixSort(d []float64, idx []int, asc bool) {
+	if len(d) <= 1 {
+		return
+	}
+
+	nanFirst := f.policy == NaNFirst
+
+	keys := make([]uint64, len(d))
+	for x, v := range d {
+		keys[x] = float64RadixKey(v, asc, nanFirst)
+	}
+
+	radixSortKeys(keys, d, idx)
+}
+
+//
+// Floats64IndirectRadixSort will sort the data using radix sort and return
+// the sorted index.
+// It is an alternative of Floats64IndirectSort that run in linear time.
+//
+func Floats64IndirectRadixSort(d []float64, asc bool) (sortedIdx []int) {
+	return Floats64With(NaNPropagate).IndirectRadixSort(d, asc)
+}
+
+//
+// IndirectRadixSort sort the data and return the sorted index like
+// Floats64IndirectRadixSort, with NaN placed following the policy of `f`.
+//
+func (f Floats64Ops) IndirectRadixSort(d []float64, asc bool) (
+	sortedIdx []int,
+) {
+	dlen := len(d)
+
+	sortedIdx = make([]int, dlen)
+	for i := 0; i < dlen; i++ {
+		sortedIdx[i] = i
+	}
+
+	f.RadixSort(d, sortedIdx, asc)
+
+	return
+}
+
+//
+// radixKey convert integer `v` into unsigned key that preserve the order of
+// value, by flipping the sign bit of signed integer.
+// On descending order all bits of key is inverted.
+//
+func radixKey[T Integer](v T, asc bool) (key uint64) {
+	var zero T
+
+	if ^zero < 0 {
+		// Signed integer.
+		key = uint64(int64(v)) ^ (1 << 63)
+	} else {
+		key = uint64(v)
+	}
+	if !asc {
+		key = ^key
+	}
+	return key
+}
+
+//
+// radixSortKeys sort the `keys` in ascending order and move the data `d` and
+// index `idx` along with it.
+// Pass where all keys have the same byte value is skipped.
+//
+func radixSortKeys[T Number](keys []uint64, d []T, idx []int) {
+	n := len(keys)
+
+	srcKeys, dstKeys := keys, make([]uint64, n)
+	srcd, dstd := d, make([]T, n)
+
+	var srcIdx, dstIdx []int
+	if idx != nil {
+		srcIdx, dstIdx = idx, make([]int, n)
+	}
+
+	var count [256]int
+
+	for shift := uint(0); shift < 64; shift += 8 {
+		count = [256]int{}
+		for _, k := range srcKeys {
+			count[byte(k>>shift)]++
+		}
+		if count[byte(srcKeys[0]>>shift)] == n {
+			continue
+		}
+
+		pos := 0
+		for b, c := range count {
+			count[b] = pos
+			pos += c
+		}
+
+		for x, k := range srcKeys {
+			b := byte(k >> shift)
+			p := count[b]
+			count[b]++
+
+			dstKeys[p] = k
+			dstd[p] = srcd[x]
+			if idx != nil {
+				dstIdx[p] = srcIdx[x]
+			}
+		}
+
+		srcKeys, dstKeys = dstKeys, srcKeys
+		srcd, dstd = dstd, srcd
+		srcIdx, dstIdx = dstIdx, srcIdx
+	}
+
+	// The last pass may leave the result in temporary buffers.
+	if &srcd[0] != &d[0] {
+		copy(keys, srcKeys)
+		copy(d, srcd)
+		copy(idx, srcIdx)
+	}
+}
+
+//
+// float64RadixKey convert float `v` into unsigned key that preserve the order
+// of value.
+// On descending order all bits of key is inverted.
+// NaN is mapped to the minimum key if `nanFirst` is true, otherwise to the
+// maximum key, in both order.
+//
+func float64RadixKey(v float64, asc, nanFirst bool) (key uint64) {
+	if math.IsNaN(v) {
+		if nanFirst {
+			return 0
+		}
+		return math.MaxUint64
+	}
+
+	key = math.Float64bits(v)
+	if key>>63 == 1 {
+		key = ^key
+	} else {
+		key |= 1 << 63
+	}
+	if !asc {
+		key = ^key
+	}
+	return key
+}
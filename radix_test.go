@@ -0,0 +1,147 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIntsIndirectRadixSort(t *testing.T) {
+	for x := range dInts {
+		d := make([]int, len(dInts[x]))
+		copy(d, dInts[x])
+
+		numerus.IntsIndirectRadixSort(d, true)
+
+		assert(t, dIntsSorted[x], d, true)
+
+		copy(d, dInts[x])
+
+		numerus.IntsIndirectRadixSort(d, false)
+
+		assert(t, dIntsSortedDesc[x], d, true)
+	}
+}
+
+func TestIntsIndirectRadixSort_Stability(t *testing.T) {
+	in := []int{1, 0, 1, 0, 1, 0}
+
+	got := numerus.IntsIndirectRadixSort(in, true)
+
+	assert(t, []int{1, 3, 5, 0, 2, 4}, got, true)
+
+	in = []int{1, 0, 1, 0, 1, 0}
+
+	got = numerus.IntsIndirectRadixSort(in, false)
+
+	assert(t, []int{0, 2, 4, 1, 3, 5}, got, true)
+}
+
+func TestInts64IndirectRadixSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	in := make([]int64, 1000)
+	for x := range in {
+		in[x] = r.Int63() - r.Int63()
+	}
+	in[0] = math.MinInt64
+	in[1] = math.MaxInt64
+	in[2] = 0
+	in[3] = -1
+
+	exp := make([]int64, len(in))
+	copy(exp, in)
+	sort.Slice(exp, func(i, j int) bool { return exp[i] < exp[j] })
+
+	got := make([]int64, len(in))
+	copy(got, in)
+
+	ids := numerus.Ints64IndirectRadixSort(got, true)
+
+	assert(t, exp, got, true)
+	for x, id := range ids {
+		assert(t, in[id], got[x], true)
+	}
+
+	sort.Slice(exp, func(i, j int) bool { return exp[i] > exp[j] })
+
+	numerus.Ints64IndirectRadixSort(got, false)
+
+	assert(t, exp, got, true)
+}
+
+func TestRadixSortUnsigned(t *testing.T) {
+	d := []uint32{math.MaxUint32, 0, 7, 1 << 31, 3}
+	exp := []uint32{0, 3, 7, 1 << 31, math.MaxUint32}
+
+	numerus.RadixSort(d, nil, true)
+
+	assert(t, exp, d, true)
+}
+
+func TestFloats64IndirectRadixSort(t *testing.T) {
+	for x := range dFloats64 {
+		d := make([]float64, len(dFloats64[x]))
+		copy(d, dFloats64[x])
+
+		numerus.Floats64IndirectRadixSort(d, true)
+
+		assert(t, dFloats64Sorted[x], d, true)
+
+		copy(d, dFloats64[x])
+
+		numerus.Floats64IndirectRadixSort(d, false)
+
+		assert(t, dFloats64SortedDesc[x], d, true)
+	}
+}
+
+func TestFloats64IndirectRadixSort_Special(t *testing.T) {
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+	inf := math.Inf(1)
+
+	in := []float64{nan, 1, negZero, -inf, 0, inf, -1, nan}
+
+	d := make([]float64, len(in))
+	copy(d, in)
+
+	gotIds := numerus.Floats64IndirectRadixSort(d, true)
+
+	assert(t, []int{3, 6, 2, 4, 1, 5, 0, 7}, gotIds, true)
+	assert(t, true, math.Signbit(d[2]), true)
+	assert(t, false, math.Signbit(d[3]), true)
+
+	copy(d, in)
+
+	gotIds = numerus.Floats64IndirectRadixSort(d, false)
+
+	assert(t, []int{5, 1, 4, 2, 6, 3, 0, 7}, gotIds, true)
+}
+
+func TestFloats64IndirectRadixSort_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	in := make([]float64, 1000)
+	for x := range in {
+		in[x] = r.NormFloat64() * 1e6
+	}
+
+	exp := make([]float64, len(in))
+	copy(exp, in)
+	sort.Float64s(exp)
+
+	got := make([]float64, len(in))
+	copy(got, in)
+
+	ids := numerus.Floats64IndirectRadixSort(got, true)
+
+	assert(t, exp, got, true)
+	for x, id := range ids {
+		assert(t, in[id], got[x], true)
+	}
+}
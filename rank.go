@@ -0,0 +1,112 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// RankMethod define how Rank assign the rank of equal elements.
+//
+type RankMethod int
+
+// List of rank method.
+const (
+	// RankMin assign the lowest rank in the group of equal elements to
+	// all of them.
+	// Example, [10 20 20 30] is ranked as [1 2 2 4].
+	RankMin RankMethod = iota
+
+	// RankMax assign the highest rank in the group of equal elements to
+	// all of them.
+	// Example, [10 20 20 30] is ranked as [1 3 3 4].
+	RankMax
+
+	// RankDense is like RankMin, but the rank of the next group is always
+	// one greater than the previous group.
+	// Example, [10 20 20 30] is ranked as [1 2 2 3].
+	RankDense
+
+	// RankOrdinal assign distinct rank to each element, where equal
+	// elements are ranked in the order they appear in data.
+	// Example, [10 20 20 30] is ranked as [1 2 3 4].
+	RankOrdinal
+)
+
+//
+// Rank return the rank of each element in `d`, where the smallest element
+// has rank 1 if `asc` is true, or the largest element has rank 1 if its
+// false.
+// The rank of equal elements is assigned using `method`; unknown method is
+// handled as RankOrdinal.
+// The data `d` is not modified.
+//
+func Rank[T Number](d []T, asc bool, method RankMethod) (ranks []int) {
+	ranks = make([]int, len(d))
+
+	rankGroups(Argsort(d, asc), func(a, b int) bool {
+		return d[a] == d[b]
+	}, func(ids []int, start, end, dense int) {
+		for x := start; x < end; x++ {
+			ranks[ids[x]] = rankOf(method, x, start, end, dense)
+		}
+	})
+
+	return ranks
+}
+
+//
+// RankAverage return the rank of each element in `d`, where equal elements
+// are assigned the average of their ranks.
+// Example, [10 20 20 30] is ranked as [1 2.5 2.5 4] in ascending order.
+// The data `d` is not modified.
+//
+func RankAverage[T Number](d []T, asc bool) (ranks []float64) {
+	ranks = make([]float64, len(d))
+
+	rankGroups(Argsort(d, asc), func(a, b int) bool {
+		return d[a] == d[b]
+	}, func(ids []int, start, end, _ int) {
+		avg := float64(start+1+end) / 2
+		for x := start; x < end; x++ {
+			ranks[ids[x]] = avg
+		}
+	})
+
+	return ranks
+}
+
+//
+// rankGroups iterate the sorted index `ids` by group of equal elements, and
+// call `fn` for each group with the range of group, `ids[start:end]`, and
+// their dense rank.
+//
+func rankGroups(ids []int, equal func(a, b int) bool,
+	fn func(ids []int, start, end, dense int),
+) {
+	dense := 0
+	for start := 0; start < len(ids); {
+		end := start + 1
+		for end < len(ids) && equal(ids[start], ids[end]) {
+			end++
+		}
+		dense++
+		fn(ids, start, end, dense)
+		start = end
+	}
+}
+
+//
+// rankOf return the rank of element at position `x` in sorted data, which
+// is in the group of equal elements at `[start:end]`.
+//
+func rankOf(method RankMethod, x, start, end, dense int) int {
+	switch method {
+	case RankMin:
+		return start + 1
+	case RankMax:
+		return end
+	case RankDense:
+		return dense
+	}
+	return x + 1
+}
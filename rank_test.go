@@ -0,0 +1,103 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	d := []int{30, 10, 20, 20}
+
+	cases := []struct {
+		method numerus.RankMethod
+		asc    bool
+		exp    []int
+	}{{
+		method: numerus.RankMin,
+		asc:    true,
+		exp:    []int{4, 1, 2, 2},
+	}, {
+		method: numerus.RankMax,
+		asc:    true,
+		exp:    []int{4, 1, 3, 3},
+	}, {
+		method: numerus.RankDense,
+		asc:    true,
+		exp:    []int{3, 1, 2, 2},
+	}, {
+		method: numerus.RankOrdinal,
+		asc:    true,
+		exp:    []int{4, 1, 2, 3},
+	}, {
+		method: numerus.RankMin,
+		asc:    false,
+		exp:    []int{1, 4, 2, 2},
+	}, {
+		method: numerus.RankOrdinal,
+		asc:    false,
+		exp:    []int{1, 4, 2, 3},
+	}}
+
+	for _, c := range cases {
+		got := numerus.IntsRank(d, c.asc, c.method)
+
+		assert(t, c.exp, got, true)
+	}
+
+	assert(t, []int{30, 10, 20, 20}, d, true)
+	assert(t, []int{}, numerus.Ints64Rank(nil, true, numerus.RankMin),
+		true)
+}
+
+func TestRankAverage(t *testing.T) {
+	d := []int64{30, 10, 20, 20, 20}
+
+	got := numerus.Ints64RankAverage(d, true)
+
+	assert(t, []float64{5, 1, 3, 3, 3}, got, true)
+
+	got = numerus.IntsRankAverage([]int{1, 2, 2, 1}, false)
+
+	assert(t, []float64{3.5, 1.5, 1.5, 3.5}, got, true)
+}
+
+func TestFloats64Rank(t *testing.T) {
+	d := []float64{2, nan, 1, 2, nan}
+
+	cases := []struct {
+		policy numerus.NaNPolicy
+		exp    []int
+		expAvg string
+	}{{
+		policy: numerus.NaNPropagate,
+		exp:    []int{2, 4, 1, 2, 4},
+		expAvg: "[2.5 4.5 1 2.5 4.5]",
+	}, {
+		policy: numerus.NaNFirst,
+		exp:    []int{4, 1, 3, 4, 1},
+		expAvg: "[4.5 1.5 3 4.5 1.5]",
+	}, {
+		policy: numerus.NaNSkip,
+		exp:    []int{2, 0, 1, 2, 0},
+		expAvg: "[2.5 NaN 1 2.5 NaN]",
+	}}
+
+	for _, c := range cases {
+		f := numerus.Floats64With(c.policy)
+
+		got := f.Rank(d, true, numerus.RankMin)
+
+		assert(t, c.exp, got, true)
+
+		gotAvg := f.RankAverage(d, true)
+
+		assert(t, c.expAvg, fmt.Sprint(gotAvg), true)
+	}
+
+	assert(t, "[2 NaN 1 2 NaN]", fmt.Sprint(d), true)
+}
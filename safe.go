@@ -0,0 +1,51 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// SafeSwap swap two indices value of slice, like Swap, but return an error
+// if one of the index is out of range.
+//
+// The returned error is *IndexError, which match ErrIndexOutOfRange.
+//
+func SafeSwap[T Number](d []T, x, y int) error {
+	const fn = "numerus: SafeSwap"
+
+	for _, i := range []int{x, y} {
+		if i < 0 || i >= len(d) {
+			return &IndexError{Func: fn, Index: i, Len: len(d)}
+		}
+	}
+
+	d[x], d[y] = d[y], d[x]
+	return nil
+}
+
+//
+// SafeSortByIndex will sort the slice `d` using sorted index `sortedIds`,
+// like SortByIndex, but validate the index before changing `d`.
+//
+// If the length of `sortedIds` is not equal to length of `d`, it will return
+// *LengthError, which match ErrLengthMismatch.
+// If one of index is out of range, it will return *IndexError, which match
+// ErrIndexOutOfRange.
+// On error, `d` is not changed.
+//
+func SafeSortByIndex[T Number](d *[]T, sortedIds []int) error {
+	const fn = "numerus: SafeSortByIndex"
+
+	if len(sortedIds) != len(*d) {
+		return &LengthError{Func: fn, Len: len(sortedIds), Exp: len(*d)}
+	}
+	for _, id := range sortedIds {
+		if id < 0 || id >= len(*d) {
+			return &IndexError{Func: fn, Index: id, Len: len(*d)}
+		}
+	}
+
+	SortByIndex(d, sortedIds)
+
+	return nil
+}
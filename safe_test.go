@@ -0,0 +1,81 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestSwap_OutOfRange(t *testing.T) {
+	d := []int{1, 2, 3}
+
+	for _, c := range [][2]int{{0, 3}, {3, 0}, {-1, 0}, {0, -1}, {5, 5}} {
+		numerus.IntsSwap(d, c[0], c[1])
+		numerus.Floats64Swap([]float64{1, 2, 3}, c[0], c[1])
+		numerus.Ints64Swap([]int64{1, 2, 3}, c[0], c[1])
+
+		assert(t, []int{1, 2, 3}, d, true)
+	}
+
+	numerus.IntsSwap(d, 0, 2)
+
+	assert(t, []int{3, 2, 1}, d, true)
+}
+
+func TestSafeSwap(t *testing.T) {
+	d := []float64{1, 2, 3}
+
+	err := numerus.Floats64SafeSwap(d, 0, 2)
+
+	assert(t, nil, err, true)
+	assert(t, []float64{3, 2, 1}, d, true)
+
+	err = numerus.IntsSafeSwap([]int{1, 2, 3}, 0, 3)
+
+	assert(t, "numerus: SafeSwap: index 3 out of range [0:3]",
+		err.Error(), true)
+	assert(t, true, errors.Is(err, numerus.ErrIndexOutOfRange), true)
+
+	err = numerus.Ints64SafeSwap([]int64{}, -1, 0)
+
+	var ierr *numerus.IndexError
+
+	assert(t, true, errors.As(err, &ierr), true)
+	assert(t, -1, ierr.Index, true)
+	assert(t, 0, ierr.Len, true)
+}
+
+func TestSafeSortByIndex(t *testing.T) {
+	d := []int{5, 6, 7}
+
+	err := numerus.IntsSafeSortByIndex(&d, []int{2, 0, 1})
+
+	assert(t, nil, err, true)
+	assert(t, []int{7, 5, 6}, d, true)
+
+	err = numerus.IntsSafeSortByIndex(&d, []int{2, 0})
+
+	assert(t, "numerus: SafeSortByIndex: length of slices does not "+
+		"match: got 2, want 3", err.Error(), true)
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+	assert(t, []int{7, 5, 6}, d, true)
+
+	d64 := []int64{1, 2}
+	err = numerus.Ints64SafeSortByIndex(&d64, []int{1, 2})
+
+	assert(t, true, errors.Is(err, numerus.ErrIndexOutOfRange), true)
+	assert(t, []int64{1, 2}, d64, true)
+
+	f64 := []float64{1, 2}
+	err = numerus.Floats64SafeSortByIndex(&f64, []int{0, -2})
+
+	var ierr *numerus.IndexError
+
+	assert(t, true, errors.As(err, &ierr), true)
+	assert(t, -2, ierr.Index, true)
+	assert(t, false, errors.Is(err, numerus.ErrLengthMismatch), true)
+}
@@ -0,0 +1,110 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"sort"
+)
+
+//
+// LowerBound return the first index in sorted slice `d` where `v` can be
+// inserted without breaking the order, which is the index of first element
+// that is not placed before `v`.
+// The `d` must be sorted in ascending order if `asc` is true, or descending
+// order otherwise.
+//
+// Example, given data [1 2 2 3] in ascending order and v 2, it will return
+// 1.
+//
+func LowerBound[T Number](d []T, v T, asc bool) int {
+	return sort.Search(len(d), func(i int) bool {
+		return compare(d[i], v, asc) >= 0
+	})
+}
+
+//
+// UpperBound return the last index in sorted slice `d` where `v` can be
+// inserted without breaking the order, which is the index of first element
+// that is placed after `v`.
+//
+// Example, given data [1 2 2 3] in ascending order and v 2, it will return
+// 3.
+//
+func UpperBound[T Number](d []T, v T, asc bool) int {
+	return sort.Search(len(d), func(i int) bool {
+		return compare(d[i], v, asc) > 0
+	})
+}
+
+//
+// EqualRange return the range of index, `d[lo:hi]`, that contains elements
+// equal to `v` in sorted slice `d`.
+// If `v` does not exist, `lo` is equal to `hi`.
+//
+func EqualRange[T Number](d []T, v T, asc bool) (lo, hi int) {
+	return LowerBound(d, v, asc), UpperBound(d, v, asc)
+}
+
+//
+// IsExistSorted will return true if value `v` exist in sorted slice `d`,
+// otherwise it will return false.
+// It is equal to IsExist but run in O(log n).
+//
+func IsExistSorted[T Number](d []T, v T, asc bool) bool {
+	i := LowerBound(d, v, asc)
+	return i < len(d) && d[i] == v
+}
+
+//
+// CountSorted will count number of `v` in sorted slice `d`.
+// It is equal to Count but run in O(log n).
+//
+func CountSorted[T Number](d []T, v T, asc bool) int {
+	lo, hi := EqualRange(d, v, asc)
+	return hi - lo
+}
+
+//
+// Nearest return the index of element in sorted slice `d` that has the
+// smallest distance to `v`.
+// If two elements have the same distance, the lower index is returned.
+//
+// If `d` is empty, it will return -1 and false.
+//
+// Example, given data [1 4 6] in ascending order and v 5, it will return 1.
+//
+func Nearest[T Number](d []T, v T, asc bool) (i int, ok bool) {
+	if len(d) == 0 {
+		return -1, false
+	}
+
+	i = LowerBound(d, v, asc)
+	switch {
+	case i == len(d):
+		i--
+	case i > 0 && asc && !gapLess(d[i]-v, v-d[i-1]):
+		// d[i-1] < v <= d[i].
+		i--
+	case i > 0 && !asc && !gapLess(v-d[i], d[i-1]-v):
+		// d[i-1] > v >= d[i].
+		i--
+	}
+	return i, true
+}
+
+//
+// gapLess will return true if the difference `a` is less than `b`, where
+// both of them is the result of subtracting smaller value from larger
+// value.
+// On signed integer, the difference may overflow and wrap into negative
+// value, which means its larger than any difference that does not
+// overflow.
+//
+func gapLess[T Number](a, b T) bool {
+	if (a < 0) != (b < 0) {
+		return b < 0
+	}
+	return a < b
+}
@@ -0,0 +1,153 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"math"
+	"testing"
+)
+
+func TestLowerUpperBound(t *testing.T) {
+	asc := []int{1, 2, 2, 3}
+	desc := []int{3, 2, 2, 1}
+
+	cases := []struct {
+		v          int
+		lo, hi     int
+		dlo, dhi   int
+		count      int
+		exist      bool
+		nearest    int
+		nearestDsc int
+	}{
+		{0, 0, 0, 4, 4, 0, false, 0, 3},
+		{1, 0, 1, 3, 4, 1, true, 0, 3},
+		{2, 1, 3, 1, 3, 2, true, 1, 1},
+		{3, 3, 4, 0, 1, 1, true, 3, 0},
+		{4, 4, 4, 0, 0, 0, false, 3, 0},
+	}
+
+	for _, c := range cases {
+		assert(t, c.lo, numerus.IntsLowerBound(asc, c.v, true), true)
+		assert(t, c.hi, numerus.IntsUpperBound(asc, c.v, true), true)
+
+		lo, hi := numerus.IntsEqualRange(desc, c.v, false)
+
+		assert(t, c.dlo, lo, true)
+		assert(t, c.dhi, hi, true)
+
+		got := numerus.IntsCountSorted(asc, c.v, true)
+
+		assert(t, c.count, got, true)
+		assert(t, c.exist, numerus.IntsIsExistSorted(desc, c.v, false),
+			true)
+
+		got, _ = numerus.IntsNearest(asc, c.v, true)
+
+		assert(t, c.nearest, got, true)
+
+		got, _ = numerus.IntsNearest(desc, c.v, false)
+
+		assert(t, c.nearestDsc, got, true)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	got, ok := numerus.Nearest([]uint8{1, 4, 6}, 5, true)
+
+	assert(t, 1, got, true)
+	assert(t, true, ok, true)
+
+	got, ok = numerus.Ints64Nearest(nil, 5, true)
+
+	assert(t, -1, got, true)
+	assert(t, false, ok, true)
+	// The difference near the bounds of type overflow on signed
+	// integer.
+	cases := []struct {
+		d   []int8
+		v   int8
+		asc bool
+		exp int
+	}{
+		{[]int8{-128, 127}, 100, true, 1},
+		{[]int8{-128, 127}, -100, true, 0},
+		{[]int8{-128, 127}, 0, true, 1},
+		{[]int8{-128, 127}, -1, true, 0},
+		{[]int8{-128, 126}, -1, true, 0},
+		{[]int8{127, -128}, 100, false, 0},
+		{[]int8{127, -128}, -100, false, 1},
+		{[]int8{127, -128}, -1, false, 1},
+		{[]int8{126, -128}, -1, false, 0},
+	}
+	for _, c := range cases {
+		got, _ = numerus.Nearest(c.d, c.v, c.asc)
+
+		assert(t, c.exp, got, true)
+	}
+
+	got, _ = numerus.Ints64Nearest([]int64{math.MinInt64, math.MaxInt64},
+		math.MaxInt64-1, true)
+
+	assert(t, 1, got, true)
+}
+
+func TestFloats64Search(t *testing.T) {
+	d := make([]float64, len(dFloats64NaN))
+
+	f := numerus.Floats64With(numerus.NaNLast)
+
+	copy(d, dFloats64NaN)
+	f.IndirectSort(d, true)
+
+	// d: [-Inf -1 -0 0 3 +Inf NaN NaN]
+	lo, hi := f.EqualRange(d, 0, true)
+
+	assert(t, 2, lo, true)
+	assert(t, 4, hi, true)
+
+	lo, hi = f.EqualRange(d, nan, true)
+
+	assert(t, 6, lo, true)
+	assert(t, 8, hi, true)
+
+	got, ok := f.Nearest(d, 100, true, -1)
+
+	assert(t, 4, got, true)
+	assert(t, true, ok, true)
+
+	got, ok = f.Nearest(d, 2.2, true, 0.5)
+
+	assert(t, -1, got, true)
+	assert(t, false, ok, true)
+
+	got, ok = f.Nearest(d, 2.6, true, 0.5)
+
+	assert(t, 4, got, true)
+	assert(t, true, ok, true)
+
+	got, _ = f.Nearest(d, posInf, true, 0)
+
+	assert(t, 5, got, true)
+
+	f = numerus.Floats64With(numerus.NaNFirst)
+
+	copy(d, dFloats64NaN)
+	f.IndirectSort(d, false)
+
+	// d: [NaN NaN +Inf 3 -0 0 -1 -Inf]
+	assert(t, 2, f.CountSorted(d, 0, false), true)
+	assert(t, true, f.IsExistSorted(d, -1, false), true)
+	assert(t, false, f.IsExistSorted(d, 1, false), true)
+
+	got, _ = f.Nearest(d, -100, false, -1)
+
+	assert(t, 6, got, true)
+
+	got, _ = f.Nearest(d, 1e300, false, -1)
+
+	assert(t, 3, got, true)
+}
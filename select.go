@@ -0,0 +1,199 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// NthElement partially sort the data `d` in place, so the element at
+// position `n` is the element that would be there if `d` is sorted in
+// ascending order.
+// All elements before `n` is less or equal to `d[n]`, and all elements after
+// `n` is greater or equal to `d[n]`.
+//
+// The index `idx` is permuted in step with `d`, and the returned index `i` is
+// `idx[n]`, the original index of selected element.
+// If `idx` is nil, `i` will be -1.
+//
+// If `n` is out of range, it will return -1 in value and index, and false in
+// ok.
+//
+// It use introselect: quickselect with median of three as pivot, and
+// fallback to median of medians when the range is not halved after
+// introselectSteps partitions, so the time complexity is linear in the worst
+// case.
+//
+func NthElement[T Number](d []T, idx []int, n int) (v T, i int, ok bool) {
+	if n < 0 || n >= len(d) {
+		v--
+		return v, -1, false
+	}
+
+	introselect(d, idx, 0, len(d), n, false)
+
+	i = -1
+	if idx != nil {
+		i = idx[n]
+	}
+	return d[n], i, true
+}
+
+//
+// Select return the `n`-th smallest element in `d` and its index, without
+// modifying `d`.
+// See NthElement for more information.
+//
+// Example, given data [5 1 4 2 5] and n 1, it will return 2 as value and 3 as
+// index.
+//
+func Select[T Number](d []T, n int) (v T, i int, ok bool) {
+	if n < 0 || n >= len(d) {
+		v--
+		return v, -1, false
+	}
+
+	tmp := make([]T, len(d))
+	copy(tmp, d)
+	ids := IntCreateSeq(0, len(d)-1)
+
+	return NthElement(tmp, ids, n)
+}
+
+//
+// Median return the median of data and its index, without modifying `d`.
+// If length of data is even, it will return the lower median.
+//
+// If data is empty, it will return -1 in value and index, and false in ok.
+//
+func Median[T Number](d []T) (v T, i int, ok bool) {
+	return Select(d, (len(d)-1)/2)
+}
+
+//
+// introselectSteps is the number of partitions using median of three as
+// pivot that must at least halve the range, otherwise introselect switch to
+// median of medians.
+//
+const introselectSteps = 3
+
+//
+// introselect select the `n`-th element in `d[l:r]`.
+// If `mom` is true, median of medians is always used as pivot.
+//
+// The size of range is checked every introselectSteps partitions; if its not
+// halved, the pivot from median of three is not making progress and median
+// of medians is used for the rest of selection.
+// Each partition cost at most the size of range at the last check, and that
+// size is halved on each check, so the total cost is linear.
+//
+func introselect[T Number](d []T, idx []int, l, r, n int, mom bool) {
+	var (
+		p     int
+		size  = r - l
+		steps int
+	)
+
+	for r-l > SortThreshold {
+		if mom {
+			p = medianOfMedians(d, idx, l, r)
+		} else {
+			p = medianOfThree(d, l, l+(r-l)/2, r-1)
+		}
+
+		lt, gt := partition3(d, idx, l, r, d[p])
+		switch {
+		case n < lt:
+			r = lt
+		case n >= gt:
+			l = gt
+		default:
+			return
+		}
+
+		if mom {
+			continue
+		}
+		steps++
+		if steps == introselectSteps {
+			if 2*(r-l) > size {
+				mom = true
+			}
+			size, steps = r-l, 0
+		}
+	}
+
+	InsertionSort(d, idx, l, r, true)
+}
+
+//
+// medianOfThree return the index of median value between d[a], d[b], and
+// d[c].
+//
+func medianOfThree[T Number](d []T, a, b, c int) int {
+	if d[a] > d[b] {
+		a, b = b, a
+	}
+	if d[b] > d[c] {
+		b = c
+		if d[a] > d[b] {
+			b = a
+		}
+	}
+	return b
+}
+
+//
+// medianOfMedians sort each group of five elements in `d[l:r]`, move their
+// median to the beginning of range, and return the index of median of those
+// medians.
+//
+func medianOfMedians[T Number](d []T, idx []int, l, r int) int {
+	w := l
+	for x := l; x < r; x += 5 {
+		e := x + 5
+		if e > r {
+			e = r
+		}
+		InsertionSort(d, idx, x, e, true)
+		swapWithIndex(d, idx, w, x+(e-x-1)/2)
+		w++
+	}
+
+	m := l + (w-l-1)/2
+	introselect(d, idx, l, w, m, true)
+
+	return m
+}
+
+//
+// partition3 partition the `d[l:r]` into three parts: the values that is less
+// than pivot `pv` in `d[l:lt]`, equal in `d[lt:gt]`, and greater in `d[gt:r]`.
+//
+func partition3[T Number](d []T, idx []int, l, r int, pv T) (lt, gt int) {
+	lt, gt = l, r
+	for x := l; x < gt; {
+		switch {
+		case d[x] < pv:
+			swapWithIndex(d, idx, lt, x)
+			lt++
+			x++
+		case d[x] > pv:
+			gt--
+			swapWithIndex(d, idx, x, gt)
+		default:
+			x++
+		}
+	}
+	return lt, gt
+}
+
+//
+// swapWithIndex swap the value of `d` and `idx` at index `x` and `y`.
+// If `idx` is nil only `d` is swapped.
+//
+func swapWithIndex[T Number](d []T, idx []int, x, y int) {
+	d[x], d[y] = d[y], d[x]
+	if idx != nil {
+		idx[x], idx[y] = idx[y], idx[x]
+	}
+}
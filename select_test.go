@@ -0,0 +1,213 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	d := []int{5, 1, 4, 2, 5}
+
+	gotv, goti, gotok := numerus.IntsSelect(d, 1)
+
+	assert(t, 2, gotv, true)
+	assert(t, 3, goti, true)
+	assert(t, true, gotok, true)
+	assert(t, []int{5, 1, 4, 2, 5}, d, true)
+
+	gotv, goti, gotok = numerus.IntsSelect(d, 5)
+
+	assert(t, -1, gotv, true)
+	assert(t, -1, goti, true)
+	assert(t, false, gotok, true)
+}
+
+func TestNthElementRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{1, 5, 8, 31, 100, 1000} {
+		for _, maxv := range []int64{3, 1000} {
+			in := make([]int64, size)
+			for x := range in {
+				in[x] = r.Int63n(maxv)
+			}
+
+			exp := make([]int64, size)
+			copy(exp, in)
+			sort.Slice(exp, func(i, j int) bool {
+				return exp[i] < exp[j]
+			})
+
+			for n := 0; n < size; n += 1 + size/10 {
+				d := make([]int64, size)
+				copy(d, in)
+				ids := numerus.IntCreateSeq(0, size-1)
+
+				gotv, goti, _ := numerus.Ints64NthElement(
+					d, ids, n)
+
+				assert(t, exp[n], gotv, true)
+				assert(t, in[goti], gotv, true)
+				for x := range d {
+					assert(t, in[ids[x]], d[x], true)
+					if x < n {
+						assert(t, true, d[x] <= gotv,
+							true)
+					} else {
+						assert(t, true, d[x] >= gotv,
+							true)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNthElementSorted(t *testing.T) {
+	// Sorted and reversed data with many elements.
+	size := 10000
+	asc := numerus.IntCreateSeq(0, size-1)
+	desc := make([]int, size)
+	for x := range desc {
+		desc[x] = size - 1 - x
+	}
+
+	gotv, goti, _ := numerus.NthElement(asc, nil, 1234)
+
+	assert(t, 1234, gotv, true)
+	assert(t, -1, goti, true)
+
+	gotv, _, _ = numerus.NthElement(desc, nil, 4321)
+
+	assert(t, 4321, gotv, true)
+}
+
+//
+// medianOfThreeKiller create the sequence from Musser's paper that make
+// quickselect with median of three as pivot to remove only two elements on
+// each partition.
+//
+func medianOfThreeKiller(size int) (d []int) {
+	k := size / 2
+	d = make([]int, 2*k)
+	for i := 1; i <= k; i++ {
+		if i%2 == 1 {
+			d[i-1] = i
+			d[i] = k + i
+		}
+		d[k+i-1] = 2 * i
+	}
+	return d
+}
+
+func TestNthElementKiller(t *testing.T) {
+	for _, size := range []int{100, 1000, 10000} {
+		in := medianOfThreeKiller(size)
+
+		for _, n := range []int{0, size / 4, size / 2, size - 1} {
+			d := make([]int, len(in))
+			copy(d, in)
+			ids := numerus.IntCreateSeq(0, len(d)-1)
+
+			gotv, goti, _ := numerus.NthElement(d, ids, n)
+
+			assert(t, n+1, gotv, true)
+			assert(t, in[goti], gotv, true)
+			for x := range d {
+				assert(t, in[ids[x]], d[x], true)
+				if x < n {
+					assert(t, true, d[x] <= gotv, true)
+				} else {
+					assert(t, true, d[x] >= gotv, true)
+				}
+			}
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	gotv, goti, gotok := numerus.Median([]float32{0.3, 0.1, 0.2, 0.4})
+
+	assert(t, float32(0.2), gotv, true)
+	assert(t, 2, goti, true)
+	assert(t, true, gotok, true)
+
+	gotiv, goti, _ := numerus.IntsMedian(dInts[1])
+
+	assert(t, 4, gotiv, true)
+	assert(t, 9, goti, true)
+
+	_, _, gotok = numerus.Ints64Median(nil)
+
+	assert(t, false, gotok, true)
+}
+
+func TestFloats64MedianNaN(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	gotv, goti, _ := f.Median(dFloats64NaN)
+
+	assert(t, "NaN", fmt.Sprint(gotv), true)
+	assert(t, 1, goti, true)
+
+	gotv, goti, _ = f.Select(dFloats64NaN, 7)
+
+	assert(t, "NaN", fmt.Sprint(gotv), true)
+	assert(t, 5, goti, true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	// Sorted non-NaN: [-Inf -1 -0 0 3 +Inf]
+	gotv, goti, _ = f.Median(dFloats64NaN)
+
+	assert(t, "-0", fmt.Sprint(gotv), true)
+	assert(t, 4, goti, true)
+
+	f = numerus.Floats64With(numerus.NaNFirst)
+
+	gotv, goti, _ = f.Select(dFloats64NaN, 2)
+
+	assert(t, negInf, gotv, true)
+	assert(t, 6, goti, true)
+}
+
+func TestFloats64SelectNaNSkip(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNSkip)
+
+	_, goti, ok := f.Select([]float64{nan, 1}, 1)
+
+	assert(t, -1, goti, true)
+	assert(t, false, ok, true)
+
+	// Sorted non-NaN: [1 2 3]
+	d := []float64{3, nan, 1, 2, nan}
+
+	gotv, goti, ok := f.Select(d, 2)
+
+	assert(t, float64(3), gotv, true)
+	assert(t, 0, goti, true)
+	assert(t, true, ok, true)
+
+	_, _, ok = f.Select(d, 3)
+
+	assert(t, false, ok, true)
+
+	medv, medi, _ := f.Median(d)
+	gotv, goti, _ = f.Select(d, (3-1)/2)
+
+	assert(t, float64(2), medv, true)
+	assert(t, medv, gotv, true)
+	assert(t, medi, goti, true)
+
+	ids := numerus.IntCreateSeq(0, len(d)-1)
+	_, _, ok = f.NthElement(d, ids, 4)
+
+	assert(t, false, ok, true)
+}
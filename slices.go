@@ -0,0 +1,553 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+)
+
+//
+// FindMax given slice of number, return the maximum value in slice and its
+// index.
+//
+// If data is empty, it will return `-1` in value and index, and false in ok.
+// For unsigned types the returned value is -1 wrapped around, which is the
+// maximum value of the type.
+//
+// Example, given a slice of data: [0 1 2 3 4], it will return 4 as max and 4
+// as index of maximum value.
+//
+func FindMax[T Number](d []T) (maxv T, maxi int, ok bool) {
+	l := len(d)
+	if l <= 0 {
+		maxv--
+		return maxv, -1, false
+	}
+
+	x := 0
+	maxv = d[x]
+	maxi = x
+
+	for x = 1; x < l; x++ {
+		if d[x] > maxv {
+			maxv = d[x]
+			maxi = x
+		}
+	}
+
+	return maxv, maxi, true
+}
+
+//
+// FindMin given slice of number, return the minimum value in slice and its
+// index.
+//
+// If data is empty, return -1 in value and index, and false in ok.
+// For unsigned types the returned value is -1 wrapped around, which is the
+// maximum value of the type.
+//
+// Example, given a slice of data: [0 1 2 3 4], it will return 0 as min and 0
+// as minimum index.
+//
+func FindMin[T Number](d []T) (minv T, mini int, ok bool) {
+	l := len(d)
+	if l <= 0 {
+		minv--
+		return minv, -1, false
+	}
+
+	x := 0
+	minv = d[x]
+	mini = x
+
+	for x = 1; x < l; x++ {
+		if d[x] < minv {
+			minv = d[x]
+			mini = x
+		}
+	}
+
+	return minv, mini, true
+}
+
+//
+// Sum return sum of all value in slice.
+//
+func Sum[T Number](d []T) (sum T) {
+	for _, v := range d {
+		sum += v
+	}
+	return sum
+}
+
+//
+// Count will count number of class in data.
+//
+func Count[T Number](d []T, class T) (count int) {
+	if len(d) <= 0 {
+		return
+	}
+
+	for _, v := range d {
+		if v == class {
+			count++
+		}
+	}
+	return count
+}
+
+//
+// Counts will count class in data and return each of the counter.
+//
+// For example, if data is "[1,1,2]" and class is "[1,2]", this function will
+// return "[2,1]".
+//
+//	idx class  count
+//	0 : 1   -> 2
+//	1 : 2   -> 1
+//
+// The data is scanned once, using the frequency table from Frequencies.
+//
+func Counts[T Number](d, classes []T) (counts []int) {
+	if len(classes) <= 0 {
+		return
+	}
+
+	return newFreqTable(d).counts(classes)
+}
+
+//
+// MaxCountOf will count number of occurence of each element of classes in
+// data and return the class with maximum count.
+//
+// If `classes` is empty, it will return -1 and false.
+// If `data` is empty, it will return -2 and false.
+// If classes has the same count value, then the first max in the class will be
+// returned.
+//
+// For example, given a data [5, 6, 5, 6, 5] and classes [5, 6, 7], the
+// function will count 5 as 3, 6 as 2, and 7 as 0.
+// Since frequency of 5 is greater than 6 and 7, then it will return `5` and
+// `true`.
+//
+func MaxCountOf[T Number](d, classes []T) (class T, ok bool) {
+	if len(classes) == 0 {
+		class--
+		return class, false
+	}
+	if len(d) == 0 {
+		class -= 2
+		return class, false
+	}
+
+	counts := Counts(d, classes)
+
+	_, maxi, _ := FindMax(counts)
+	if maxi < 0 {
+		class--
+		return class, false
+	}
+
+	return classes[maxi], true
+}
+
+//
+// Swap swap two indices value of slice.
+// If one of the index is out of range, the slice is not changed; use
+// SafeSwap to get an error instead.
+//
+func Swap[T Number](d []T, x, y int) {
+	if x == y {
+		return
+	}
+	if x < 0 || y < 0 || x >= len(d) || y >= len(d) {
+		return
+	}
+
+	tmp := d[x]
+	d[x] = d[y]
+	d[y] = tmp
+}
+
+//
+// IsExist will return true if value `v` exist in slice of `d`, otherwise it
+// will return false.
+//
+func IsExist[T Number](d []T, v T) bool {
+	for _, x := range d {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// InsertionSort will sort the data using insertion-sort algorithm.
+//
+// Parameters:
+// - `data` is slice that will be sorted.
+// - `idx` is indices of data.
+// - `l` is starting index of slice to be sorted.
+// - `r` is end index of slice to be sorted.
+//
+// The sort is stable, equal elements keep their original order.
+//
+func InsertionSort[T Number](d []T, ids []int, l, r int, asc bool) {
+	for x := l + 1; x < r; x++ {
+		for y := x; y > l; y-- {
+			if !isBefore(d[y], d[y-1], asc) {
+				break
+			}
+			Swap(ids, y, y-1)
+			Swap(d, y, y-1)
+		}
+	}
+}
+
+//
+// SortByIndex will sort the slice `d` using sorted index `sortedIds`.
+//
+func SortByIndex[T Number](d *[]T, sortedIds []int) {
+	newd := make([]T, len(*d))
+
+	for i := range sortedIds {
+		newd[i] = (*d)[sortedIds[i]]
+	}
+
+	(*d) = newd
+}
+
+//
+// InplaceMergesort in-place merge-sort without memory allocation.
+//
+// The sort is stable: equal elements, and their index in `idx`, keep their
+// original order, in both ascending and descending order.
+// If the data is already sorted, it will return immediately.
+//
+func InplaceMergesort[T Number](d []T, idx []int, l, r int, asc bool) {
+	if isSortedRange(d, l, r, asc) {
+		return
+	}
+	inplaceMergesort(d, idx, l, r, asc, SortThreshold)
+}
+
+//
+// inplaceMergesort sort the `d[l:r]` using merge-sort, where the range that
+// is less or equal than `threshold` is sorted using insertion sort.
+//
+func inplaceMergesort[T Number](d []T, idx []int, l, r int, asc bool,
+	threshold int,
+) {
+	// (0) If data length == Threshold, then
+	if l+threshold >= r {
+		// (0.1) use insertion sort.
+		InsertionSort(d, idx, l, r, asc)
+		return
+	}
+
+	// (1) Divide into left and right.
+	res := (r + l) % 2
+	c := (r + l) / 2
+	if res == 1 {
+		c++
+	}
+
+	// (2) Sort left.
+	inplaceMergesort(d, idx, l, c, asc, threshold)
+
+	// (3) Sort right.
+	inplaceMergesort(d, idx, c, r, asc, threshold)
+
+	// (4) Merge sorted left and right.
+	mergeHalves(d, idx, l, c, r, asc)
+}
+
+//
+// InplaceMergesortParallel sort the data using the same in-place merge-sort
+// as InplaceMergesort, but the left and right halves is sorted concurrently
+// when the length of data is greater or equal to ParallelThreshold.
+//
+// The `workers` parameter limit the number of goroutines that sort the data
+// at the same time, including the caller.
+// If `workers` is less or equal to zero, it will use GOMAXPROCS.
+//
+// The result, including the order of equal elements in `d` and `idx`, is the
+// same as InplaceMergesort.
+//
+func InplaceMergesortParallel[T Number](d []T, idx []int, l, r int, asc bool,
+	workers int,
+) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if isSortedRange(d, l, r, asc) {
+		return
+	}
+
+	sem := make(chan struct{}, workers-1)
+
+	parallelMergesort(d, idx, l, r, asc, sem)
+}
+
+func parallelMergesort[T Number](d []T, idx []int, l, r int, asc bool,
+	sem chan struct{},
+) {
+	if r-l < ParallelThreshold {
+		inplaceMergesort(d, idx, l, r, asc, SortThreshold)
+		return
+	}
+
+	res := (r + l) % 2
+	c := (r + l) / 2
+	if res == 1 {
+		c++
+	}
+
+	select {
+	case sem <- struct{}{}:
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		go func() {
+			parallelMergesort(d, idx, l, c, asc, sem)
+			<-sem
+			wg.Done()
+		}()
+
+		parallelMergesort(d, idx, c, r, asc, sem)
+
+		wg.Wait()
+	default:
+		// No worker available, sort both halves in this goroutine.
+		parallelMergesort(d, idx, l, c, asc, sem)
+		parallelMergesort(d, idx, c, r, asc, sem)
+	}
+
+	mergeHalves(d, idx, l, c, r, asc)
+}
+
+//
+// mergeHalves merge the sorted left, d[l:c], and sorted right, d[c:r].
+//
+func mergeHalves[T Number](d []T, idx []int, l, c, r int, asc bool) {
+	if asc {
+		if d[c-1] <= d[c] {
+			// (4.1) If the last element of the left is lower then
+			// the first element of the right, i.e. [1 2] [3 4];
+			// no merging needed, return immediately.
+			return
+		}
+	} else {
+		if d[c-1] >= d[c] {
+			return
+		}
+	}
+
+	inplaceMerge(d, idx, l, c, r, asc)
+}
+
+//
+// inplaceMerge merge the sorted `d[a:m]` and `d[m:b]` using symMerge, moving
+// the index `idx` in step with `d`.
+//
+func inplaceMerge[T Number](d []T, idx []int, a, m, b int, asc bool) {
+	symMerge(a, m, b, func(i, j int) bool {
+		return isBefore(d[i], d[j], asc)
+	}, func(i, j int) {
+		Swap(idx, i, j)
+		Swap(d, i, j)
+	})
+}
+
+//
+// symMerge merge the sorted range [a, m) and [m, b) using the SymMerge
+// algorithm by Pok-Son Kim and Arne Kutzner, "Stable Minimum Storage Merging
+// by Symmetric Comparisons".
+// The two halves is merged by rotating the elements, so no memory allocation
+// is needed and the order of equal elements is preserved.
+//
+// The `less(i, j)` must return true if element at position `i` must be
+// placed before element at position `j`, and `swap(i, j)` swap the element
+// at position `i` and `j`.
+//
+func symMerge(a, m, b int, less func(i, j int) bool, swap func(i, j int)) {
+	// (4.2) If the left side only have one element, find the position of
+	// element `a` in the right side using binary search and move it
+	// there.
+	if m-a == 1 {
+		x, y := m, b
+		for x < y {
+			h := int(uint(x+y) >> 1)
+			if less(h, a) {
+				x = h + 1
+			} else {
+				y = h
+			}
+		}
+		for k := a; k < x-1; k++ {
+			swap(k, k+1)
+		}
+		return
+	}
+
+	// (4.3) If the right side only have one element, find the position of
+	// element `m` in the left side using binary search and move it there.
+	if b-m == 1 {
+		x, y := a, m
+		for x < y {
+			h := int(uint(x+y) >> 1)
+			if !less(m, h) {
+				x = h + 1
+			} else {
+				y = h
+			}
+		}
+		for k := m; k > x; k-- {
+			swap(k, k-1)
+		}
+		return
+	}
+
+	// (4.4) Find the range [start, end) around `m` that need to be
+	// rotated, so all elements in [a, mid) is placed before all elements
+	// in [mid, b).
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotate(start, m, end, swap)
+	}
+
+	// (4.5) Merge the left and right side of `mid` recursively.
+	if a < start && start < mid {
+		symMerge(a, start, mid, less, swap)
+	}
+	if mid < end && end < b {
+		symMerge(mid, end, b, less, swap)
+	}
+}
+
+//
+// isBefore return true if `a` must be placed before `b`, which is when `a` is
+// less than `b` in ascending order or greater than `b` in descending order.
+//
+func isBefore[T Number](a, b T, asc bool) bool {
+	if asc {
+		return a < b
+	}
+	return a > b
+}
+
+//
+// rotate swap the range [a, m) and [m, b) using block swap.
+//
+func rotate(a, m, b int, swap func(i, j int)) {
+	x := m - a
+	y := b - m
+
+	for x != y {
+		if x > y {
+			swapRange(m-x, m, y, swap)
+			x -= y
+		} else {
+			swapRange(m-x, m+y-x, x, swap)
+			y -= x
+		}
+	}
+	swapRange(m-x, m, x, swap)
+}
+
+//
+// swapRange swap `n` elements starting at position `a` with `n` elements
+// starting at position `b`.
+//
+func swapRange(a, b, n int, swap func(i, j int)) {
+	for x := 0; x < n; x++ {
+		swap(a+x, b+x)
+	}
+}
+
+//
+// IndirectSort will sort the data and return the sorted index.
+// The sort is stable, see InplaceMergesort.
+//
+func IndirectSort[T Number](d []T, asc bool) (sortedIdx []int) {
+	dlen := len(d)
+
+	sortedIdx = make([]int, dlen)
+	for i := 0; i < dlen; i++ {
+		sortedIdx[i] = i
+	}
+
+	InplaceMergesort(d, sortedIdx, 0, dlen, asc)
+
+	return
+}
+
+//
+// Argsort return the index that would sort the data, in ascending order if
+// `asc` is true or descending if its false, without modifying `d`.
+//
+// The sort is stable, the index of equal elements keep their original order.
+//
+// Example, given data [3 1 2 1], it will return [1 3 2 0] in ascending order
+// and [0 2 1 3] in descending order.
+//
+func Argsort[T Number](d []T, asc bool) (sortedIdx []int) {
+	sortedIdx = make([]int, len(d))
+	for i := range sortedIdx {
+		sortedIdx[i] = i
+	}
+
+	slices.SortStableFunc(sortedIdx, func(a, b int) int {
+		return compare(d[a], d[b], asc)
+	})
+
+	return sortedIdx
+}
+
+//
+// compare return -1 if `a` should be placed before `b`, 1 if `a` should be
+// placed after `b`, or 0 if they are equal.
+//
+func compare[T Number](a, b T, asc bool) int {
+	switch {
+	case a < b:
+		if asc {
+			return -1
+		}
+		return 1
+	case a > b:
+		if asc {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
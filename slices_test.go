@@ -0,0 +1,201 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type celsius float32
+
+func TestFindMaxEmpty(t *testing.T) {
+	gotv, goti, gotok := numerus.FindMax([]int32{})
+
+	assert(t, int32(-1), gotv, true)
+	assert(t, -1, goti, true)
+	assert(t, false, gotok, true)
+
+	gotu, goti, gotok := numerus.FindMax([]uint8{})
+
+	assert(t, uint8(255), gotu, true)
+	assert(t, -1, goti, true)
+	assert(t, false, gotok, true)
+}
+
+func TestFindMax(t *testing.T) {
+	gotv, goti, gotok := numerus.FindMax([]celsius{5, 6, 7, 8, 9, 0, 1})
+
+	assert(t, celsius(9), gotv, true)
+	assert(t, 4, goti, true)
+	assert(t, true, gotok, true)
+}
+
+func TestFindMin(t *testing.T) {
+	gotv, goti, gotok := numerus.FindMin([]uint64{5, 6, 7, 8, 9, 0, 1})
+
+	assert(t, uint64(0), gotv, true)
+	assert(t, 5, goti, true)
+	assert(t, true, gotok, true)
+}
+
+func TestSum(t *testing.T) {
+	got := numerus.Sum([]int32{5, 6, 7, 8, 9, 0, 1, 2, 3, 4})
+
+	assert(t, int32(45), got, true)
+}
+
+func TestCounts(t *testing.T) {
+	d := []uint16{1, 1, 2, 2, 3, 1, 2}
+	classes := []uint16{1, 2, 3}
+	exp := []int{3, 3, 1}
+
+	got := numerus.Counts(d, classes)
+
+	assert(t, exp, got, true)
+	assert(t, 3, numerus.Count(d, 2), true)
+}
+
+func TestMaxCountOf(t *testing.T) {
+	d := []celsius{5, 6, 5, 6, 5}
+
+	got, ok := numerus.MaxCountOf(d, []celsius{5, 6, 7})
+
+	assert(t, celsius(5), got, true)
+	assert(t, true, ok, true)
+
+	got, ok = numerus.MaxCountOf(d, nil)
+
+	assert(t, celsius(-1), got, true)
+	assert(t, false, ok, true)
+
+	got, ok = numerus.MaxCountOf(nil, []celsius{5})
+
+	assert(t, celsius(-2), got, true)
+	assert(t, false, ok, true)
+}
+
+func TestIsExist(t *testing.T) {
+	d := []int8{-3, -2, -1, 0, 1}
+
+	assert(t, true, numerus.IsExist(d, -2), true)
+	assert(t, false, numerus.IsExist(d, 2), true)
+}
+
+func TestIndirectSort(t *testing.T) {
+	d := []float32{0.5, 0.6, 0.7, 0.8, 0.9, 0.0, 0.1, 0.2, 0.3, 0.4}
+	exp := []float32{0.0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	expIds := []int{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}
+
+	gotIds := numerus.IndirectSort(d, true)
+
+	assert(t, exp, d, true)
+	assert(t, expIds, gotIds, true)
+}
+
+func TestIndirectSortDesc(t *testing.T) {
+	d := []celsius{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}
+	exp := []celsius{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	numerus.IndirectSort(d, false)
+
+	assert(t, exp, d, true)
+}
+
+func TestSortByIndex(t *testing.T) {
+	d := []uint{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}
+	ids := []int{5, 6, 7, 8, 9, 0, 1, 2, 3, 4}
+	exp := []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	numerus.SortByIndex(&d, ids)
+
+	assert(t, exp, d, true)
+}
+
+func testInplaceMergesortParallel[T numerus.Number](t *testing.T, d []T) {
+	for _, asc := range []bool{true, false} {
+		for _, workers := range []int{0, 1, 3} {
+			exp := make([]T, len(d))
+			copy(exp, d)
+			expIds := numerus.IntCreateSeq(0, len(d)-1)
+
+			got := make([]T, len(d))
+			copy(got, d)
+			gotIds := numerus.IntCreateSeq(0, len(d)-1)
+
+			numerus.InplaceMergesort(exp, expIds, 0, len(exp), asc)
+			numerus.InplaceMergesortParallel(got, gotIds, 0,
+				len(got), asc, workers)
+
+			assert(t, exp, got, true)
+			assert(t, expIds, gotIds, true)
+		}
+	}
+}
+
+func TestInplaceMergesortParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	size := numerus.ParallelThreshold*2 + 13
+
+	ints := make([]int, size)
+	floats := make([]float64, size)
+	for x := range ints {
+		// Use small range of value to produce many equal elements.
+		ints[x] = r.Intn(100) - 50
+		floats[x] = float64(r.Intn(1000)) / 10
+	}
+
+	testInplaceMergesortParallel(t, ints)
+	testInplaceMergesortParallel(t, floats)
+	testInplaceMergesortParallel(t, inSorts[6])
+}
+
+func testInplaceMergesortStability[T numerus.Number](t *testing.T, in []T) {
+	for _, asc := range []bool{true, false} {
+		d := make([]T, len(in))
+		copy(d, in)
+		ids := numerus.IntCreateSeq(0, len(in)-1)
+
+		expIds := numerus.IntCreateSeq(0, len(in)-1)
+		sort.SliceStable(expIds, func(x, y int) bool {
+			if asc {
+				return in[expIds[x]] < in[expIds[y]]
+			}
+			return in[expIds[x]] > in[expIds[y]]
+		})
+
+		numerus.InplaceMergesort(d, ids, 0, len(d), asc)
+
+		assert(t, expIds, ids, true)
+		for x := range d {
+			assert(t, in[ids[x]], d[x], true)
+		}
+	}
+}
+
+func TestInplaceMergesort_Stability(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for size := 0; size < 100; size++ {
+		ints := make([]int, size)
+		floats := make([]float64, size)
+		for x := range ints {
+			ints[x] = r.Intn(5)
+			floats[x] = float64(r.Intn(10)) / 10
+		}
+
+		testInplaceMergesortStability(t, ints)
+		testInplaceMergesortStability(t, floats)
+	}
+
+	ints := make([]int, 10000)
+	for x := range ints {
+		ints[x] = r.Intn(100)
+	}
+
+	testInplaceMergesortStability(t, ints)
+}
@@ -0,0 +1,164 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// SortAlgorithm define the algorithm that is used by Sort.
+//
+type SortAlgorithm int
+
+// List of sort algorithm.
+const (
+	// SortAuto select the algorithm based on the data: already sorted
+	// data is returned as is, data with few natural runs is sorted using
+	// timsort, and the rest is sorted using pdqsort.
+	// Since pdqsort is not stable, the result of SortAuto is not stable.
+	SortAuto SortAlgorithm = iota
+
+	// SortMergesort use the in-place merge-sort, the same algorithm
+	// used by InplaceMergesort.
+	// It is stable and does not allocate memory.
+	SortMergesort
+
+	// SortPdqsort use the pattern-defeating quicksort.
+	// It is not stable, does not allocate memory, and run in
+	// O(n*log(n)) on the worst case.
+	// It is fast on random data and data with many duplicates.
+	SortPdqsort
+
+	// SortTimsort use the timsort, merging the natural runs in data.
+	// It is stable and allocate temporary buffer at most half of the data
+	// length.
+	// It is fast on data that is nearly sorted.
+	SortTimsort
+)
+
+//
+// SortOptions define the options for Sort.
+//
+type SortOptions struct {
+	// Algorithm to be used to sort the data.
+	// Default to SortAuto.
+	Algorithm SortAlgorithm
+
+	// Threshold when the length of partition is less or equal to
+	// Threshold, insertion sort will be used to replace merge-sort or
+	// pdqsort.
+	// Default to SortThreshold.
+	//
+	// Only Sort and their Ints, Ints64, and Floats64 wrappers use this
+	// Threshold; the other sort functions always use SortThreshold.
+	Threshold int
+}
+
+//
+// Sort sort the data `d[l:r]` using the algorithm in `opts` and move the
+// index `idx` in step with `d`; if `idx` is nil only `d` will be sorted.
+// If `opts` is nil, it will use the default options.
+//
+func Sort[T Number](d []T, idx []int, l, r int, asc bool, opts *SortOptions) {
+	if opts == nil {
+		opts = &SortOptions{}
+	}
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = SortThreshold
+	}
+
+	if r-l <= 1 {
+		return
+	}
+
+	s := &sorter[T]{
+		d:   d[l:r],
+		asc: asc,
+	}
+	if idx != nil {
+		s.idx = idx[l:r]
+	}
+
+	switch opts.Algorithm {
+	case SortMergesort:
+		inplaceMergesort(s.d, s.idx, 0, len(s.d), asc, threshold)
+	case SortPdqsort:
+		s.pdqsort(threshold)
+	case SortTimsort:
+		s.timsort()
+	default:
+		s.auto(threshold)
+	}
+}
+
+//
+// sorter contains the data and index that is sorted together.
+//
+type sorter[T Number] struct {
+	d   []T
+	idx []int
+	asc bool
+}
+
+func (s *sorter[T]) less(i, j int) bool {
+	return isBefore(s.d[i], s.d[j], s.asc)
+}
+
+func (s *sorter[T]) swap(i, j int) {
+	swapWithIndex(s.d, s.idx, i, j)
+}
+
+//
+// auto select the sort algorithm by counting the natural runs in data.
+// Counting is stopped as soon as the data is known to contains too many
+// runs for timsort.
+//
+func (s *sorter[T]) auto(threshold int) {
+	n := len(s.d)
+	if n <= threshold {
+		s.insertionSort(0, n)
+		return
+	}
+
+	maxRuns := 1 + n/256
+	runs := 0
+	for start := 0; start < n && runs <= maxRuns; runs++ {
+		start = s.countRun(start, n)
+	}
+
+	switch {
+	case runs == 1 && s.isAscRun(0, n):
+		return
+	case runs <= maxRuns:
+		s.timsort()
+	default:
+		s.pdqsort(threshold)
+	}
+}
+
+//
+// isAscRun will return true if `d[l:r]` is not in strictly descending order.
+//
+func (s *sorter[T]) isAscRun(l, r int) bool {
+	return r-l < 2 || !s.less(l+1, l)
+}
+
+//
+// insertionSort sort the `d[a:b]` using stable insertion sort.
+//
+func (s *sorter[T]) insertionSort(a, b int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && s.less(j, j-1); j-- {
+			s.swap(j, j-1)
+		}
+	}
+}
+
+//
+// reverse the order of `d[a:b]`.
+//
+func (s *sorter[T]) reverse(a, b int) {
+	for i, j := a, b-1; i < j; i, j = i+1, j-1 {
+		s.swap(i, j)
+	}
+}
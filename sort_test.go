@@ -0,0 +1,147 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var sortAlgorithms = []numerus.SortAlgorithm{
+	numerus.SortAuto,
+	numerus.SortMergesort,
+	numerus.SortPdqsort,
+	numerus.SortTimsort,
+}
+
+//
+// sortPatterns generate the data with various patterns: random, many
+// duplicates, sorted, reversed, sawtooth, and nearly sorted.
+//
+func sortPatterns(r *rand.Rand, size int) (patterns [][]int) {
+	random := make([]int, size)
+	dups := make([]int, size)
+	sorted := make([]int, size)
+	reversed := make([]int, size)
+	sawtooth := make([]int, size)
+	nearly := make([]int, size)
+
+	for x := 0; x < size; x++ {
+		random[x] = r.Intn(size*10 + 1)
+		dups[x] = r.Intn(4)
+		sorted[x] = x / 3
+		reversed[x] = size - x/3
+		sawtooth[x] = x % 17
+		nearly[x] = x
+	}
+	for x := 0; x < size/50; x++ {
+		i, j := r.Intn(size), r.Intn(size)
+		nearly[i], nearly[j] = nearly[j], nearly[i]
+	}
+
+	return [][]int{random, dups, sorted, reversed, sawtooth, nearly}
+}
+
+func testSort(t *testing.T, in []int, algo numerus.SortAlgorithm, asc bool) {
+	d := make([]int, len(in))
+	copy(d, in)
+	ids := numerus.IntCreateSeq(0, len(in)-1)
+	if ids == nil {
+		ids = []int{}
+	}
+
+	expIds := make([]int, len(in))
+	copy(expIds, ids)
+	sort.SliceStable(expIds, func(x, y int) bool {
+		if asc {
+			return in[expIds[x]] < in[expIds[y]]
+		}
+		return in[expIds[x]] > in[expIds[y]]
+	})
+
+	opts := &numerus.SortOptions{
+		Algorithm: algo,
+		Threshold: 3,
+	}
+
+	numerus.IntsSort(d, ids, 0, len(d), asc, opts)
+
+	for x := range d {
+		assert(t, in[expIds[x]], d[x], true)
+		assert(t, in[ids[x]], d[x], true)
+	}
+	assert(t, true, numerus.PermIsValid(ids), true)
+
+	if algo == numerus.SortMergesort || algo == numerus.SortTimsort {
+		assert(t, expIds, ids, true)
+	}
+}
+
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{0, 1, 2, 5, 8, 49, 50, 64, 65, 500, 3000} {
+		for _, in := range sortPatterns(r, size) {
+			for _, algo := range sortAlgorithms {
+				testSort(t, in, algo, true)
+				testSort(t, in, algo, false)
+			}
+		}
+	}
+}
+
+func TestSort_Range(t *testing.T) {
+	for _, algo := range sortAlgorithms {
+		d := []int64{9, 5, 6, 3, 4, 1, 2, 0}
+		ids := []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+		numerus.Ints64Sort(d, ids, 1, 7, true,
+			&numerus.SortOptions{Algorithm: algo})
+
+		assert(t, []int64{9, 1, 2, 3, 4, 5, 6, 0}, d, true)
+		assert(t, []int{0, 5, 6, 3, 4, 1, 2, 7}, ids, true)
+	}
+}
+
+func TestSort_NilIndex(t *testing.T) {
+	for _, algo := range sortAlgorithms {
+		d := []uint8{5, 1, 4, 1, 3, 9, 2, 6, 5, 3, 5}
+
+		numerus.Sort(d, nil, 0, len(d), false,
+			&numerus.SortOptions{Algorithm: algo})
+
+		assert(t, []uint8{9, 6, 5, 5, 5, 4, 3, 3, 2, 1, 1}, d, true)
+	}
+
+	d := []int{3, 1, 2}
+	numerus.Sort(d, nil, 0, len(d), true, nil)
+
+	assert(t, []int{1, 2, 3}, d, true)
+}
+
+func TestFloats64Sort(t *testing.T) {
+	for _, algo := range sortAlgorithms {
+		f := numerus.Floats64With(numerus.NaNFirst)
+
+		d := make([]float64, len(dFloats64NaN))
+		copy(d, dFloats64NaN)
+		ids := numerus.IntCreateSeq(0, len(d)-1)
+
+		f.Sort(d, ids, 0, len(d), true,
+			&numerus.SortOptions{Algorithm: algo})
+
+		got := fmt.Sprint(d)
+
+		// Pdqsort is not stable, -0 and 0 may be swapped.
+		if got != "[NaN NaN -Inf -1 0 -0 3 +Inf]" {
+			assert(t, "[NaN NaN -Inf -1 -0 0 3 +Inf]", got,
+				true)
+		}
+		assert(t, []int{1, 5, 6, 3}, ids[:4], true)
+	}
+}
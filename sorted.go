@@ -0,0 +1,100 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// Run define the range of index, `d[Start:End]`, that is already sorted in
+// data.
+//
+type Run struct {
+	Start int
+	End   int
+
+	// Asc is true if the run is in ascending order, where each element is
+	// greater or equal to the previous element.
+	// If its false, the run is in strictly descending order, where each
+	// element is less than the previous element.
+	Asc bool
+}
+
+//
+// IsSorted will return true if data is sorted in ascending order if `asc` is
+// true, or in descending order otherwise.
+// If `strict` is true, the data must not contains equal elements that is
+// next to each other.
+//
+// Empty data or data with one element is sorted.
+//
+func IsSorted[T Number](d []T, asc, strict bool) bool {
+	return isSortedFunc(len(d), strict, func(i, j int) int {
+		return compare(d[i], d[j], asc)
+	})
+}
+
+//
+// Runs return the natural runs in data, where each run is the longest
+// ascending or strictly descending sequence that start after the previous
+// run.
+// Strictly descending run can be reversed without changing the order of
+// equal elements.
+//
+// Example, given data [1 2 2 5 4 3 3 4], it will return three runs:
+// {0 4 true}, {4 6 false}, and {6 8 true}.
+//
+func Runs[T Number](d []T) (runs []Run) {
+	return runsFunc(len(d), func(i, j int) int {
+		return compare(d[i], d[j], true)
+	})
+}
+
+//
+// isSortedRange will return true if `d[l:r]` is sorted, non strictly.
+//
+func isSortedRange[T Number](d []T, l, r int, asc bool) bool {
+	for x := l + 1; x < r; x++ {
+		if isBefore(d[x], d[x-1], asc) {
+			return false
+		}
+	}
+	return true
+}
+
+//
+// isSortedFunc will return true if `n` elements is sorted using comparison
+// function `cmp`.
+//
+func isSortedFunc(n int, strict bool, cmp func(i, j int) int) bool {
+	for x := 1; x < n; x++ {
+		c := cmp(x-1, x)
+		if c > 0 || (strict && c == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+//
+// runsFunc return the natural runs of `n` elements using the comparison
+// function `cmp` that compare elements in ascending order.
+//
+func runsFunc(n int, cmp func(i, j int) int) (runs []Run) {
+	for start := 0; start < n; {
+		end := start + 1
+		asc := true
+
+		if end < n && cmp(start, end) > 0 {
+			asc = false
+			for end++; end < n && cmp(end-1, end) > 0; end++ {
+			}
+		} else {
+			for ; end < n && cmp(end-1, end) <= 0; end++ {
+			}
+		}
+
+		runs = append(runs, Run{Start: start, End: end, Asc: asc})
+		start = end
+	}
+	return runs
+}
@@ -0,0 +1,87 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestIsSorted(t *testing.T) {
+	cases := []struct {
+		d                                   []int
+		asc, ascStrict, desc, descNonStrict bool
+	}{
+		{nil, true, true, true, true},
+		{[]int{1}, true, true, true, true},
+		{[]int{1, 2, 2, 3}, true, false, false, false},
+		{[]int{1, 2, 3}, true, true, false, false},
+		{[]int{3, 2, 2, 1}, false, false, false, true},
+		{[]int{3, 2, 1}, false, false, true, true},
+		{[]int{1, 3, 2}, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		assert(t, c.asc, numerus.IntsIsSorted(c.d, true, false), true)
+		assert(t, c.ascStrict, numerus.IntsIsSorted(c.d, true, true),
+			true)
+		assert(t, c.desc, numerus.IntsIsSorted(c.d, false, true), true)
+		assert(t, c.descNonStrict,
+			numerus.IntsIsSorted(c.d, false, false), true)
+	}
+
+	for x := range dFloats64Sorted {
+		assert(t, true, numerus.Floats64IsSorted(dFloats64Sorted[x],
+			true, false), true)
+		assert(t, true, numerus.Floats64IsSorted(
+			dFloats64SortedDesc[x], false, false), true)
+	}
+}
+
+func TestRuns(t *testing.T) {
+	exp := []numerus.Run{
+		{Start: 0, End: 4, Asc: true},
+		{Start: 4, End: 6, Asc: false},
+		{Start: 6, End: 8, Asc: true},
+	}
+
+	got := numerus.Ints64Runs([]int64{1, 2, 2, 5, 4, 3, 3, 4})
+
+	assert(t, exp, got, true)
+
+	exp = []numerus.Run{
+		{Start: 0, End: 1, Asc: true},
+	}
+
+	assert(t, exp, numerus.Runs([]uint{7}), true)
+	assert(t, 0, len(numerus.Runs([]uint{})), true)
+}
+
+func TestFloats64RunsNaN(t *testing.T) {
+	f := numerus.Floats64With(numerus.NaNLast)
+
+	exp := []numerus.Run{
+		{Start: 0, End: 3, Asc: true},
+		{Start: 3, End: 5, Asc: false},
+	}
+
+	got := f.Runs([]float64{1, 2, nan, 3, 1})
+
+	assert(t, exp, got, true)
+	assert(t, true, f.IsSorted(
+		[]float64{1, 2, nan, nan}, true, false), true)
+	assert(t, false, f.IsSorted(
+		[]float64{1, 2, nan, nan}, true, true), true)
+}
+
+func TestInplaceMergesortSorted(t *testing.T) {
+	d := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	ids := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	// Already sorted data does not change the index.
+	numerus.IntsInplaceMergesort(d, ids, 0, len(d), true)
+
+	assert(t, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, ids, true)
+}
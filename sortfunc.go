@@ -0,0 +1,75 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// InplaceMergesortFunc sort the index `idx[l:r]` using the same in-place
+// merge-sort as InplaceMergesort, but the order is defined by function
+// `less`.
+//
+// The `less(i, j)` receive two values of `idx`, for example the index of
+// records, and must return true if record `i` must be placed before record
+// `j`.
+// Only `idx` is modified, so it can be used to sort records by computed keys
+// without moving the records.
+//
+// The sort is stable and does not allocate memory.
+//
+// Example, sorting index of records by their name,
+//
+//	idx := IntCreateSeq(0, len(records)-1)
+//	InplaceMergesortFunc(idx, 0, len(idx), func(i, j int) bool {
+//		return records[i].Name < records[j].Name
+//	})
+//
+func InplaceMergesortFunc(idx []int, l, r int, less func(i, j int) bool) {
+	if l+SortThreshold >= r {
+		insertionSortFunc(idx, l, r, less)
+		return
+	}
+
+	res := (r + l) % 2
+	c := (r + l) / 2
+	if res == 1 {
+		c++
+	}
+
+	InplaceMergesortFunc(idx, l, c, less)
+	InplaceMergesortFunc(idx, c, r, less)
+
+	if !less(idx[c], idx[c-1]) {
+		return
+	}
+
+	symMerge(l, c, r, func(i, j int) bool {
+		return less(idx[i], idx[j])
+	}, func(i, j int) {
+		idx[i], idx[j] = idx[j], idx[i]
+	})
+}
+
+//
+// IndirectSortFunc return the index of records, from 0 to n-1, sorted using
+// function `less`.
+// See InplaceMergesortFunc for more information.
+//
+func IndirectSortFunc(n int, less func(i, j int) bool) (sortedIdx []int) {
+	sortedIdx = make([]int, n)
+	for i := 0; i < n; i++ {
+		sortedIdx[i] = i
+	}
+
+	InplaceMergesortFunc(sortedIdx, 0, n, less)
+
+	return sortedIdx
+}
+
+func insertionSortFunc(idx []int, l, r int, less func(i, j int) bool) {
+	for x := l + 1; x < r; x++ {
+		for y := x; y > l && less(idx[y], idx[y-1]); y-- {
+			idx[y], idx[y-1] = idx[y-1], idx[y]
+		}
+	}
+}
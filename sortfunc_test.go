@@ -0,0 +1,60 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"github.com/shuLhan/numerus"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type record struct {
+	name  string
+	score int
+}
+
+func TestInplaceMergesortFunc(t *testing.T) {
+	records := []record{
+		{"a", 3}, {"b", 1}, {"c", 2}, {"d", 1}, {"e", 3}, {"f", 2},
+		{"g", 1}, {"h", 3}, {"i", 2}, {"j", 1},
+	}
+
+	idx := numerus.IntCreateSeq(0, len(records)-1)
+
+	numerus.InplaceMergesortFunc(idx, 0, len(idx), func(i, j int) bool {
+		return records[i].score < records[j].score
+	})
+
+	assert(t, []int{1, 3, 6, 9, 2, 5, 8, 0, 4, 7}, idx, true)
+}
+
+func TestIndirectSortFunc_Stability(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{0, 1, 7, 8, 9, 50, 1000} {
+		keys := make([]int, size)
+		for x := range keys {
+			keys[x] = r.Intn(10)
+		}
+
+		// Sort by computed key in descending order.
+		less := func(i, j int) bool {
+			return keys[i]%7 > keys[j]%7
+		}
+
+		exp := numerus.IntCreateSeq(0, size-1)
+		sort.SliceStable(exp, func(x, y int) bool {
+			return less(exp[x], exp[y])
+		})
+
+		got := numerus.IndirectSortFunc(size, less)
+
+		assert(t, len(exp), len(got), true)
+		for x := range exp {
+			assert(t, exp[x], got[x], true)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// SortByKey sort the slice `s` in place by the key of each element that is
+// returned by function `key`, and return the permutation that has been
+// applied to `s`, where the new s[i] is the old s[perm[i]].
+//
+// The keys are sorted using in-place merge-sort, so the sort is stable; the
+// only allocations are the keys and the permutation.
+// If the key is float, NaN is placed at the end.
+// Use SortByKeyWith to set the NaN policy on each call.
+//
+// Example, to sort slice of struct by their float field,
+//
+//	perm := SortByKey(records, func(r Record) float64 {
+//		return r.Score
+//	}, false)
+//
+func SortByKey[E any, K Number](s []E, key func(E) K, asc bool) (
+	perm []int,
+) {
+	return SortByKeyWith(s, key, asc, NaNPropagate)
+}
+
+//
+// SortByKeyWith is equal to SortByKey, but if the key is float, including
+// float32 and named type of float, NaN is placed following the NaN `policy`.
+//
+func SortByKeyWith[E any, K Number](s []E, key func(E) K, asc bool,
+	policy NaNPolicy,
+) (perm []int) {
+	n := len(s)
+
+	keys := make([]K, n)
+	perm = make([]int, n)
+	for x := range s {
+		keys[x] = key(s[x])
+		perm[x] = x
+	}
+
+	// NaN is not ordered, so its moved out before sorting the rest of keys.
+	l, r := partitionNaN(keys, perm, 0, n, policy == NaNFirst)
+
+	InplaceMergesort(keys, perm, l, r, asc)
+
+	PermApply(s, perm)
+
+	return perm
+}
@@ -0,0 +1,85 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+type person struct {
+	name  string
+	age   int
+	score float64
+}
+
+func TestSortByKey(t *testing.T) {
+	in := []person{
+		{"a", 30, 0.5},
+		{"b", 20, nan},
+		{"c", 30, 0.9},
+		{"d", 10, 0.5},
+	}
+
+	d := make([]person, len(in))
+	copy(d, in)
+
+	perm := numerus.SortByKey(d, func(r person) int {
+		return r.age
+	}, true)
+
+	assert(t, []int{3, 1, 0, 2}, perm, true)
+	for x := range d {
+		assert(t, in[perm[x]].name, d[x].name, true)
+	}
+
+	// Equal keys keep their original order in descending order.
+	copy(d, in)
+	perm = numerus.SortByKey(d, func(r person) int64 {
+		return int64(r.age)
+	}, false)
+
+	assert(t, []int{0, 2, 1, 3}, perm, true)
+
+	copy(d, in)
+	perm = numerus.SortByKeyWith(d, func(r person) float64 {
+		return r.score
+	}, false, numerus.NaNFirst)
+
+	assert(t, []int{1, 2, 0, 3}, perm, true)
+	assert(t, "b", d[0].name, true)
+
+	assert(t, []int{}, numerus.SortByKey([]person{},
+		func(r person) int { return r.age }, true), true)
+}
+
+type score float64
+
+func TestSortByKeyFloat(t *testing.T) {
+	in := []score{3, score(nan), 1, 2, score(nan), 0, 5, 4, -1, 7, 6, 9,
+		8}
+
+	d := make([]score, len(in))
+	copy(d, in)
+
+	perm := numerus.SortByKey(d, func(v score) score { return v }, true)
+
+	assert(t, "[-1 0 1 2 3 4 5 6 7 8 9 NaN NaN]", fmt.Sprint(d), true)
+	assert(t, []int{8, 5, 2, 3, 0, 7, 6, 10, 9, 12, 11, 1, 4}, perm,
+		true)
+
+	d32 := make([]float32, len(in))
+	for x, v := range in {
+		d32[x] = float32(v)
+	}
+
+	perm = numerus.SortByKeyWith(d32, func(v float32) float32 {
+		return v
+	}, false, numerus.NaNFirst)
+
+	assert(t, "[NaN NaN 9 8 7 6 5 4 3 2 1 0 -1]", fmt.Sprint(d32), true)
+	assert(t, []int{1, 4, 11, 12, 9, 10, 6, 7, 0, 3, 2, 5, 8}, perm, true)
+}
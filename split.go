@@ -0,0 +1,122 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// Split contains the result of splitting the sorted data into left
+// partition, `[0:Index]`, and right partition, `[Index:]`.
+//
+type Split struct {
+	// Index is the position in sorted data where the right partition
+	// start.
+	Index int
+
+	// Threshold is the mean of the last value in left partition and the
+	// first value in right partition.
+	Threshold float64
+
+	// Gain is the reduction of impurity after the split.
+	Gain float64
+
+	// GainRatio is the Gain divided by the entropy of partition sizes.
+	GainRatio float64
+}
+
+//
+// EvaluateSplits return all candidate splits of the sorted column `sorted`,
+// with their gain in `imp` impurity of class `labels`.
+//
+// The `sorted` and `sortedIdx` is the result of IndirectSort on the column,
+// and `labels` is the class of each row in their original order, so the
+// label of `sorted[i]` is `labels[sortedIdx[i]]`.
+// The candidate split is placed between two different values, in the order
+// of `sorted`; split next to NaN is not evaluated.
+//
+// The data is scanned once, with the class counts of both partitions
+// updated for each value, so the cost is O(n*k) with k is number of
+// classes.
+//
+// If the length of `sortedIdx` or `labels` is not equal to length of
+// `sorted`, it will return an error that match ErrLengthMismatch.
+//
+func EvaluateSplits[T Number, L Number](sorted []T, sortedIdx []int,
+	labels []L, imp Impurity,
+) (splits []Split, err error) {
+	const fn = "numerus: EvaluateSplits"
+
+	n := len(sorted)
+	for _, l := range []int{len(sortedIdx), len(labels)} {
+		if l != n {
+			return nil, &LengthError{Func: fn, Len: l, Exp: n}
+		}
+	}
+
+	var (
+		classes = discoverClasses(labels, compareNaNLast[L], false)
+		ci      = newClassIndex(classes, false)
+		left    = make([]int, len(classes))
+		right   = make([]int, len(classes))
+	)
+
+	for _, l := range labels {
+		right[ci.find(l)]++
+	}
+
+	parent := impurityOf(imp, right)
+
+	for x := 1; x < n; x++ {
+		c := ci.find(labels[sortedIdx[x-1]])
+		left[c]++
+		right[c]--
+
+		a, b := sorted[x-1], sorted[x]
+		if a == b || isNaN(a) || isNaN(b) {
+			continue
+		}
+
+		nl, nr := float64(x), float64(n-x)
+		child := (nl*impurityOf(imp, left) +
+			nr*impurityOf(imp, right)) / float64(n)
+
+		split := Split{
+			Index:     x,
+			Threshold: (float64(a) + float64(b)) / 2,
+			Gain:      parent - child,
+		}
+		if splitInfo := Entropy([]float64{nl, nr}, 2); splitInfo > 0 {
+			split.GainRatio = split.Gain / splitInfo
+		}
+
+		splits = append(splits, split)
+	}
+
+	return splits, nil
+}
+
+//
+// BestSplit return the split of the sorted column with the maximum gain.
+// If two or more splits have the same gain, the first of them is returned.
+//
+// If there is no candidate split, because all values are equal, it will
+// return false.
+//
+// See EvaluateSplits for more information.
+//
+func BestSplit[T Number, L Number](sorted []T, sortedIdx []int, labels []L,
+	imp Impurity,
+) (best Split, ok bool, err error) {
+	splits, err := EvaluateSplits(sorted, sortedIdx, labels, imp)
+	if err != nil {
+		return best, false, err
+	}
+
+	for x, split := range splits {
+		if x == 0 || split.Gain > best.Gain {
+			best = split
+		}
+	}
+
+	return best, len(splits) > 0, nil
+}
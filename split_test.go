@@ -0,0 +1,62 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shuLhan/numerus"
+	"testing"
+)
+
+func TestEvaluateSplits(t *testing.T) {
+	d := []float64{3, 1, 4, 1, 5, 2}
+	labels := []int{1, 0, 1, 0, 1, 0}
+
+	sortedIdx := numerus.Floats64IndirectSort(d, true)
+
+	splits, err := numerus.EvaluateSplits(d, sortedIdx, labels,
+		numerus.ImpurityGini)
+
+	assert(t, nil, err, true)
+
+	var got []string
+	for _, s := range splits {
+		got = append(got, fmt.Sprintf("%d %.1f %.4f %.4f", s.Index,
+			s.Threshold, s.Gain, s.GainRatio))
+	}
+
+	assert(t, []string{
+		"2 1.5 0.2500 0.2722",
+		"3 2.5 0.5000 0.5000",
+		"4 3.5 0.2500 0.2722",
+		"5 4.5 0.1000 0.1538",
+	}, got, true)
+
+	best, ok, err := numerus.BestSplit(d, sortedIdx, labels,
+		numerus.ImpurityEntropy)
+
+	assert(t, nil, err, true)
+	assert(t, true, ok, true)
+	assert(t, 3, best.Index, true)
+	assert(t, 2.5, best.Threshold, true)
+	assert(t, float64(1), best.Gain, true)
+}
+
+func TestBestSplit(t *testing.T) {
+	d := []int{7, 7, 7}
+	labels := []float64{1, 0, 1}
+
+	_, ok, err := numerus.BestSplit(d, []int{0, 1, 2}, labels,
+		numerus.ImpurityMisclassification)
+
+	assert(t, nil, err, true)
+	assert(t, false, ok, true)
+
+	_, _, err = numerus.BestSplit(d, []int{0, 1}, labels,
+		numerus.ImpurityGini)
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+}
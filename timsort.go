@@ -0,0 +1,252 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// timsort sort the data by finding the natural runs in data and merging
+// them, while keeping the length of pending runs balanced.
+// Run that is shorter than minimum run length is extended using binary
+// insertion sort.
+//
+func (s *sorter[T]) timsort() {
+	var (
+		n      = len(s.d)
+		minRun = timsortMinRun(n)
+		runs   []Run
+		buf    = &timsortBuffer[T]{}
+	)
+
+	for lo := 0; lo < n; {
+		hi := s.countRun(lo, n)
+		if !s.isAscRun(lo, hi) {
+			s.reverse(lo, hi)
+		}
+		if hi-lo < minRun {
+			end := lo + minRun
+			if end > n {
+				end = n
+			}
+			s.binaryInsertionSort(lo, hi, end)
+			hi = end
+		}
+
+		runs = append(runs, Run{Start: lo, End: hi, Asc: true})
+		runs = s.mergeCollapse(runs, buf)
+		lo = hi
+	}
+
+	for len(runs) > 1 {
+		runs = s.mergeAt(runs, len(runs)-2, buf)
+	}
+}
+
+//
+// timsortBuffer contains the temporary buffer that is used to merge the
+// runs.
+//
+type timsortBuffer[T Number] struct {
+	d   []T
+	idx []int
+}
+
+//
+// timsortMinRun return the minimum run length for data with length `n`,
+// so `n/minRun` is equal to, or slightly less than, power of two.
+//
+func timsortMinRun(n int) int {
+	r := 0
+	for n >= 64 {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+//
+// countRun return the end of natural run that start at `lo`, which is the
+// longest ascending or strictly descending sequence before `hi`.
+//
+func (s *sorter[T]) countRun(lo, hi int) int {
+	end := lo + 1
+	if end >= hi {
+		return hi
+	}
+	if s.less(end, lo) {
+		for end++; end < hi && s.less(end, end-1); end++ {
+		}
+	} else {
+		for end++; end < hi && !s.less(end, end-1); end++ {
+		}
+	}
+	return end
+}
+
+//
+// binaryInsertionSort sort the `d[lo:hi]`, where `d[lo:start]` is already
+// sorted, by inserting each element after the last equal element.
+//
+func (s *sorter[T]) binaryInsertionSort(lo, start, hi int) {
+	for x := start; x < hi; x++ {
+		v := s.d[x]
+
+		l, r := lo, x
+		for l < r {
+			m := int(uint(l+r) >> 1)
+			if isBefore(v, s.d[m], s.asc) {
+				r = m
+			} else {
+				l = m + 1
+			}
+		}
+
+		copy(s.d[l+1:x+1], s.d[l:x])
+		s.d[l] = v
+		if s.idx != nil {
+			id := s.idx[x]
+			copy(s.idx[l+1:x+1], s.idx[l:x])
+			s.idx[l] = id
+		}
+	}
+}
+
+//
+// mergeCollapse merge the pending runs until the length of each run is
+// greater than the sum of the next two runs, and greater than the next run.
+//
+func (s *sorter[T]) mergeCollapse(runs []Run, buf *timsortBuffer[T]) []Run {
+	size := func(x int) int {
+		return runs[x].End - runs[x].Start
+	}
+
+	for len(runs) > 1 {
+		n := len(runs) - 2
+		if (n > 0 && size(n-1) <= size(n)+size(n+1)) ||
+			(n > 1 && size(n-2) <= size(n-1)+size(n)) {
+			if size(n-1) < size(n+1) {
+				n--
+			}
+		} else if size(n) > size(n+1) {
+			break
+		}
+		runs = s.mergeAt(runs, n, buf)
+	}
+	return runs
+}
+
+//
+// mergeAt merge the run at index `x` and `x+1` in `runs`.
+//
+func (s *sorter[T]) mergeAt(runs []Run, x int, buf *timsortBuffer[T]) []Run {
+	a, m, b := runs[x].Start, runs[x].End, runs[x+1].End
+
+	// Skip the elements in left run that is already in place.
+	l, r := a, m
+	for l < r {
+		h := int(uint(l+r) >> 1)
+		if s.less(m, h) {
+			r = h
+		} else {
+			l = h + 1
+		}
+	}
+	a = l
+
+	// Skip the elements in right run that is already in place.
+	l, r = m, b
+	for l < r {
+		h := int(uint(l+r) >> 1)
+		if s.less(h, m-1) {
+			l = h + 1
+		} else {
+			r = h
+		}
+	}
+	b = l
+
+	if a < m && m < b {
+		if m-a <= b-m {
+			s.mergeLo(a, m, b, buf)
+		} else {
+			s.mergeHi(a, m, b, buf)
+		}
+	}
+
+	runs[x].End = runs[x+1].End
+	return append(runs[:x+1], runs[x+2:]...)
+}
+
+//
+// mergeLo merge the `d[a:m]` and `d[m:b]` from the lowest element, by
+// copying the left run into buffer.
+//
+func (s *sorter[T]) mergeLo(a, m, b int, buf *timsortBuffer[T]) {
+	left := append(buf.d[:0], s.d[a:m]...)
+	buf.d = left
+
+	var leftIdx []int
+	if s.idx != nil {
+		leftIdx = append(buf.idx[:0], s.idx[a:m]...)
+		buf.idx = leftIdx
+	}
+
+	i, j, k := 0, m, a
+	for ; i < len(left) && j < b; k++ {
+		if isBefore(s.d[j], left[i], s.asc) {
+			s.d[k] = s.d[j]
+			if s.idx != nil {
+				s.idx[k] = s.idx[j]
+			}
+			j++
+			continue
+		}
+		s.d[k] = left[i]
+		if s.idx != nil {
+			s.idx[k] = leftIdx[i]
+		}
+		i++
+	}
+
+	copy(s.d[k:], left[i:])
+	if s.idx != nil {
+		copy(s.idx[k:], leftIdx[i:])
+	}
+}
+
+//
+// mergeHi merge the `d[a:m]` and `d[m:b]` from the highest element, by
+// copying the right run into buffer.
+//
+func (s *sorter[T]) mergeHi(a, m, b int, buf *timsortBuffer[T]) {
+	right := append(buf.d[:0], s.d[m:b]...)
+	buf.d = right
+
+	var rightIdx []int
+	if s.idx != nil {
+		rightIdx = append(buf.idx[:0], s.idx[m:b]...)
+		buf.idx = rightIdx
+	}
+
+	i, j, k := len(right)-1, m-1, b-1
+	for ; i >= 0 && j >= a; k-- {
+		if isBefore(right[i], s.d[j], s.asc) {
+			s.d[k] = s.d[j]
+			if s.idx != nil {
+				s.idx[k] = s.idx[j]
+			}
+			j--
+			continue
+		}
+		s.d[k] = right[i]
+		if s.idx != nil {
+			s.idx[k] = rightIdx[i]
+		}
+		i--
+	}
+
+	copy(s.d[a:], right[:i+1])
+	if s.idx != nil {
+		copy(s.idx[a:], rightIdx[:i+1])
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus
+
+//
+// CountWeighted return the total weight of `class` in data, where each
+// value `d[x]` has weight `w[x]`.
+//
+// If the length of `d` and `w` is not equal, it will return an error that
+// match ErrLengthMismatch.
+// If one of the weight is negative or NaN, it will return an error that
+// match ErrNegativeWeight.
+//
+func CountWeighted[T Number](d []T, w []float64, class T) (
+	total float64, err error,
+) {
+	const fn = "numerus: CountWeighted"
+
+	totals, err := countsWeighted(fn, d, w, []T{class})
+	if err != nil {
+		return 0, err
+	}
+	return totals[0], nil
+}
+
+//
+// CountsWeighted return the total weight of each class in data, where each
+// value `d[x]` has weight `w[x]`.
+// The data is scanned once.
+//
+// For example, if data is [1 1 2], weights is [0.5 1 2], and classes is
+// [1 2 3], it will return [1.5 2 0].
+//
+// See CountWeighted for the possible errors.
+//
+func CountsWeighted[T Number](d []T, w []float64, classes []T) (
+	totals []float64, err error,
+) {
+	return countsWeighted("numerus: CountsWeighted", d, w, classes)
+}
+
+//
+// MaxCountOfWeighted return the class with maximum total weight in data,
+// where each value `d[x]` has weight `w[x]`.
+//
+// If `classes` is empty, it will return -1 and false.
+// If `data` is empty, it will return -2 and false.
+// If classes has the same total weight, the first of them in classes is
+// returned.
+//
+// See CountWeighted for the possible errors.
+//
+func MaxCountOfWeighted[T Number](d []T, w []float64, classes []T) (
+	class T, ok bool, err error,
+) {
+	const fn = "numerus: MaxCountOfWeighted"
+
+	totals, err := countsWeighted(fn, d, w, classes)
+	if err != nil {
+		return class, false, err
+	}
+
+	class, ok = maxCountOfWeighted(d, classes, totals)
+
+	return class, ok, nil
+}
+
+func countsWeighted[T Number](fn string, d []T, w []float64, classes []T) (
+	totals []float64, err error,
+) {
+	ft, err := newWeightedFreqTable(fn, d, w)
+	if err != nil {
+		return nil, err
+	}
+	return ft.weightsOf(classes), nil
+}
+
+//
+// maxCountOfWeighted return the class with maximum total weight, using the
+// same return values as MaxCountOf.
+//
+func maxCountOfWeighted[T Number](d, classes []T, totals []float64) (
+	class T, ok bool,
+) {
+	if len(classes) == 0 {
+		class--
+		return class, false
+	}
+	if len(d) == 0 {
+		class -= 2
+		return class, false
+	}
+
+	_, maxi, _ := FindMax(totals)
+
+	return classes[maxi], true
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Shulhan <ms@kilabit.info>. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package numerus_test
+
+import (
+	"errors"
+	"github.com/shuLhan/numerus"
+	"math"
+	"testing"
+)
+
+func TestCountsWeighted(t *testing.T) {
+	d := []int{1, 1, 2, 3}
+	w := []float64{0.5, 1, 2, 0}
+
+	got, err := numerus.IntsCountsWeighted(d, w, []int{1, 2, 3, 4})
+
+	assert(t, nil, err, true)
+	assert(t, []float64{1.5, 2, 0, 0}, got, true)
+
+	total, err := numerus.Ints64CountWeighted([]int64{1, 1, 2}, w[:3], 1)
+
+	assert(t, nil, err, true)
+	assert(t, 1.5, total, true)
+
+	_, err = numerus.IntsCountsWeighted(d, w[:2], []int{1})
+
+	assert(t, true, errors.Is(err, numerus.ErrLengthMismatch), true)
+
+	_, err = numerus.IntsCountWeighted(d, []float64{1, 1, math.NaN(), 1},
+		1)
+
+	assert(t, true, errors.Is(err, numerus.ErrNegativeWeight), true)
+}
+
+func TestMaxCountOfWeighted(t *testing.T) {
+	d := []int{5, 6, 5, 6, 5}
+	w := []float64{0.1, 1, 0.1, 1, 0.1}
+
+	got, ok, err := numerus.IntsMaxCountOfWeighted(d, w, []int{5, 6, 7})
+
+	assert(t, nil, err, true)
+	assert(t, 6, got, true)
+	assert(t, true, ok, true)
+
+	got, ok, _ = numerus.IntsMaxCountOfWeighted(d, w, nil)
+
+	assert(t, -1, got, true)
+	assert(t, false, ok, true)
+
+	got64, ok, _ := numerus.Ints64MaxCountOfWeighted(nil, nil,
+		[]int64{5})
+
+	assert(t, int64(-2), got64, true)
+	assert(t, false, ok, true)
+
+	_, _, err = numerus.Ints64MaxCountOfWeighted([]int64{5}, []float64{-1},
+		[]int64{5})
+
+	assert(t, "numerus: MaxCountOfWeighted: index 0: negative weight: -1",
+		err.Error(), true)
+}
+
+func TestFloats64CountsWeighted(t *testing.T) {
+	d := []float64{nan, 1, nan, 2}
+	w := []float64{1, 2, 3, 4}
+	classes := []float64{nan, 1, 2}
+
+	f := numerus.Floats64With(numerus.NaNPropagate)
+
+	got, err := f.CountsWeighted(d, w, classes)
+
+	assert(t, nil, err, true)
+	assert(t, []float64{4, 2, 4}, got, true)
+
+	gotc, ok, _ := f.MaxCountOfWeighted(d, w, classes)
+
+	assert(t, true, math.IsNaN(gotc), true)
+	assert(t, true, ok, true)
+
+	f = numerus.Floats64With(numerus.NaNSkip)
+
+	total, _ := f.CountWeighted(d, w, nan)
+
+	assert(t, float64(0), total, true)
+
+	gotc, _, _ = f.MaxCountOfWeighted(d, w, classes)
+
+	assert(t, float64(2), gotc, true)
+}